@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,11 +13,16 @@ import (
 )
 
 type Holiday struct {
-	Date        string `json:"date"`
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
-	Type        string `json:"type"`
-	IsOptional  bool   `json:"isOptional"`
+	Date         string  `json:"date"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description,omitempty"`
+	Type         string  `json:"type"`
+	IsOptional   bool    `json:"isOptional"`
+	WorkFraction float64 `json:"workFraction,omitempty"`
+}
+
+func (h Holiday) IsReducedDay() bool {
+	return h.WorkFraction > 0 && h.WorkFraction < 1
 }
 
 var (
@@ -47,20 +53,77 @@ func (t *TeamworkAPI) GetBrazilianHolidays(year int) (map[string]Holiday, error)
 		holidays[dateStr] = holiday
 	}
 
-	apiHolidays, err := fetchHolidaysFromAPI(year)
-	if err == nil && len(apiHolidays) > 0 {
-		holidays = apiHolidays
+	for dateStr, holiday := range getMovableHolidays(year) {
+		holidays[dateStr] = holiday
 	}
 
+	store, err := loadHolidayStore(year)
+	if err != nil {
+		t.logDebug("Erro ao ler feriados salvos de %d: %v", year, err)
+		store = &holidayStore{Year: year}
+	}
+
+	if store.needsRefresh(t.currentHolidayCalendar().RefreshDays, time.Now()) {
+		apiHolidays, err := fetchHolidaysFromAPI(year)
+		if err != nil {
+			t.logDebug("Não foi possível atualizar feriados de %d pela API: %v", year, err)
+		} else {
+			store.Remote = holidayList(apiHolidays)
+			store.FetchedAt = time.Now().Format(time.RFC3339)
+			if err := saveHolidayStore(store); err != nil {
+				t.logDebug("Erro ao salvar feriados de %d: %v", year, err)
+			}
+		}
+	}
+
+	mergeHolidays(holidays, holidayMap(store.Remote))
+	mergeHolidays(holidays, holidayMap(store.Imported))
+
 	cachedHolidays[year] = holidays
 
 	return holidays, nil
 }
 
+func invalidateHolidayCache(year int) {
+	cachedHolidaysLock.Lock()
+	delete(cachedHolidays, year)
+	cachedHolidaysLock.Unlock()
+}
+
+func (t *TeamworkAPI) RefreshHolidays(year int) (map[string]Holiday, error) {
+	apiHolidays, err := fetchHolidaysFromAPI(year)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar feriados de %d: %v", year, err)
+	}
+
+	store, err := loadHolidayStore(year)
+	if err != nil {
+		store = &holidayStore{Year: year}
+	}
+
+	store.Remote = holidayList(apiHolidays)
+	store.FetchedAt = time.Now().Format(time.RFC3339)
+	if err := saveHolidayStore(store); err != nil {
+		return nil, err
+	}
+
+	invalidateHolidayCache(year)
+	return t.GetBrazilianHolidays(year)
+}
+
 func fetchHolidaysFromAPI(year int) (map[string]Holiday, error) {
 	url := fmt.Sprintf("https://brasilapi.com.br/api/feriados/v1/%d", year)
 
-	resp, err := http.Get(url)
+	ctx, cancel := context.WithTimeout(context.Background(), holidayFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar requisição: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := getHTTPClient().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -87,16 +150,11 @@ func fetchHolidaysFromAPI(year int) (map[string]Holiday, error) {
 
 	holidays := make(map[string]Holiday)
 	for _, h := range apiHolidays {
-		dateParts := strings.Split(h.Date, "/")
-		if len(dateParts) != 3 {
+		dateStr, ok := parseHolidayDate(h.Date, year)
+		if !ok {
 			continue
 		}
 
-		day, _ := strconv.Atoi(dateParts[0])
-		month, _ := strconv.Atoi(dateParts[1])
-
-		dateStr := fmt.Sprintf("%d-%02d-%02d", year, month, day)
-
 		holiday := Holiday{
 			Date:       dateStr,
 			Name:       h.Name,
@@ -110,6 +168,93 @@ func fetchHolidaysFromAPI(year int) (map[string]Holiday, error) {
 	return holidays, nil
 }
 
+func parseHolidayDate(value string, year int) (string, bool) {
+	value = strings.TrimSpace(value)
+	if parsed, err := time.Parse("2006-01-02", value); err == nil {
+		return formatDate(parsed), true
+	}
+
+	dateParts := strings.Split(value, "/")
+	if len(dateParts) != 3 {
+		return "", false
+	}
+
+	day, errDay := strconv.Atoi(dateParts[0])
+	month, errMonth := strconv.Atoi(dateParts[1])
+	if errDay != nil || errMonth != nil || month < 1 || month > 12 || day < 1 || day > 31 {
+		return "", false
+	}
+
+	return fmt.Sprintf("%d-%02d-%02d", year, month, day), true
+}
+
+func mergeHolidays(holidays map[string]Holiday, remote map[string]Holiday) {
+	for dateStr, holiday := range remote {
+		if _, exists := holidays[dateStr]; exists {
+			continue
+		}
+		holidays[dateStr] = holiday
+	}
+}
+
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+}
+
+func getMovableHolidays(year int) map[string]Holiday {
+	pascoa := easterSunday(year)
+
+	movable := []struct {
+		offset       int
+		name         string
+		description  string
+		isOptional   bool
+		workFraction float64
+	}{
+		{-48, "Carnaval", "Ponto facultativo", true, 0},
+		{-47, "Carnaval", "Ponto facultativo", true, 0},
+		{-46, "Quarta-feira de Cinzas", "Ponto facultativo até as 14h", true, 0.5},
+		{-2, "Sexta-feira Santa", "", false, 0},
+		{0, "Páscoa", "", false, 0},
+		{60, "Corpus Christi", "Ponto facultativo", true, 0},
+	}
+
+	holidays := make(map[string]Holiday)
+	for _, item := range movable {
+		dateStr := formatDate(pascoa.AddDate(0, 0, item.offset))
+		holidayType := "nacional"
+		if item.isOptional {
+			holidayType = "facultativo"
+		}
+
+		holidays[dateStr] = Holiday{
+			Date:         dateStr,
+			Name:         item.name,
+			Description:  item.description,
+			Type:         holidayType,
+			IsOptional:   item.isOptional,
+			WorkFraction: item.workFraction,
+		}
+	}
+
+	return holidays
+}
+
 func getFixedHolidays(year int) map[string]Holiday {
 	holidays := make(map[string]Holiday)
 
@@ -162,6 +307,24 @@ func getFixedHolidays(year int) map[string]Holiday {
 		IsOptional: false,
 	}
 
+	if year >= 2024 {
+		holidays[fmt.Sprintf("%d-11-20", year)] = Holiday{
+			Date:       fmt.Sprintf("%d-11-20", year),
+			Name:       "Dia Nacional de Zumbi e da Consciência Negra",
+			Type:       "nacional",
+			IsOptional: false,
+		}
+	}
+
+	holidays[fmt.Sprintf("%d-12-24", year)] = Holiday{
+		Date:         fmt.Sprintf("%d-12-24", year),
+		Name:         "Véspera de Natal",
+		Description:  "Ponto facultativo a partir das 14h",
+		Type:         "facultativo",
+		IsOptional:   true,
+		WorkFraction: 0.5,
+	}
+
 	holidays[fmt.Sprintf("%d-12-25", year)] = Holiday{
 		Date:       fmt.Sprintf("%d-12-25", year),
 		Name:       "Natal",
@@ -169,6 +332,15 @@ func getFixedHolidays(year int) map[string]Holiday {
 		IsOptional: false,
 	}
 
+	holidays[fmt.Sprintf("%d-12-31", year)] = Holiday{
+		Date:         fmt.Sprintf("%d-12-31", year),
+		Name:         "Véspera de Ano Novo",
+		Description:  "Ponto facultativo a partir das 14h",
+		Type:         "facultativo",
+		IsOptional:   true,
+		WorkFraction: 0.5,
+	}
+
 	return holidays
 }
 
@@ -176,7 +348,7 @@ func (t *TeamworkAPI) IsHoliday(date time.Time) (bool, Holiday, error) {
 	year := date.Year()
 	dateStr := date.Format("2006-01-02")
 
-	holidays, err := t.GetBrazilianHolidays(year)
+	holidays, err := t.GetHolidayCalendar(year)
 	if err != nil {
 		return false, Holiday{}, err
 	}
@@ -186,7 +358,7 @@ func (t *TeamworkAPI) IsHoliday(date time.Time) (bool, Holiday, error) {
 }
 
 func (t *TeamworkAPI) GetHolidaysForMonth(year, month int) ([]Holiday, error) {
-	allHolidays, err := t.GetBrazilianHolidays(year)
+	allHolidays, err := t.GetHolidayCalendar(year)
 	if err != nil {
 		return nil, err
 	}
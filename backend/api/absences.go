@@ -0,0 +1,175 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Absence struct {
+	ID        string `json:"id"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	Type      string `json:"type"`
+	Period    string `json:"period,omitempty"`
+	Minutes   int    `json:"minutes,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+var absenceTypeNames = map[string]string{
+	"ferias":      "Férias",
+	"atestado":    "Atestado",
+	"folga":       "Folga",
+	"compensacao": "Compensação",
+	"licenca":     "Licença",
+	"outro":       "Ausência",
+}
+
+func ValidateAbsence(absence Absence) error {
+	inicio, err := time.Parse("2006-01-02", absence.StartDate)
+	if err != nil {
+		return fmt.Errorf("data inicial inválida: %v", err)
+	}
+
+	fim := inicio
+	if absence.EndDate != "" {
+		fim, err = time.Parse("2006-01-02", absence.EndDate)
+		if err != nil {
+			return fmt.Errorf("data final inválida: %v", err)
+		}
+	}
+
+	if fim.Before(inicio) {
+		return fmt.Errorf("a data final deve ser igual ou posterior à data inicial")
+	}
+
+	if _, ok := absenceTypeNames[strings.ToLower(strings.TrimSpace(absence.Type))]; !ok {
+		return fmt.Errorf("tipo de ausência inválido: %s (use ferias, atestado, folga, compensacao, licenca ou outro)", absence.Type)
+	}
+
+	switch absence.Period {
+	case "", "full":
+	case "morning", "afternoon":
+		if !fim.Equal(inicio) {
+			return fmt.Errorf("meio período só pode ser informado para um único dia")
+		}
+	case "partial":
+		if absence.Minutes <= 0 || absence.Minutes > 24*60 {
+			return fmt.Errorf("informe os minutos de ausência parcial (recebido %d)", absence.Minutes)
+		}
+	default:
+		return fmt.Errorf("período inválido: %s (use full, morning, afternoon ou partial)", absence.Period)
+	}
+
+	return nil
+}
+
+func (a Absence) Contains(date string) bool {
+	fim := a.EndDate
+	if fim == "" {
+		fim = a.StartDate
+	}
+	return date >= a.StartDate && date <= fim
+}
+
+func (a Absence) IsHalfDay() bool {
+	return a.Period == "morning" || a.Period == "afternoon"
+}
+
+func (a Absence) IsFullDay() bool {
+	return a.Period == "" || a.Period == "full"
+}
+
+func (a Absence) Label() string {
+	nome, ok := absenceTypeNames[strings.ToLower(a.Type)]
+	if !ok {
+		nome = absenceTypeNames["outro"]
+	}
+	if a.IsHalfDay() {
+		return nome + " (meio período)"
+	}
+	if a.Period == "partial" {
+		return fmt.Sprintf("%s (%s)", nome, formatMinutesAsHours(a.Minutes))
+	}
+	return nome
+}
+
+func (t *TeamworkAPI) SetAbsences(absences []Absence) {
+	t.settingsMutex.Lock()
+	t.absences = absences
+	t.settingsMutex.Unlock()
+
+	t.cache.DeletePrefix("dashboard_stats_")
+}
+
+func (t *TeamworkAPI) currentAbsences() []Absence {
+	t.settingsMutex.RLock()
+	defer t.settingsMutex.RUnlock()
+	return t.absences
+}
+
+func (t *TeamworkAPI) FindAbsence(date string) (*Absence, bool) {
+	var partial *Absence
+	for _, absence := range t.currentAbsences() {
+		if !absence.Contains(date) {
+			continue
+		}
+
+		if absence.IsFullDay() {
+			return &absence, true
+		}
+		if partial == nil {
+			partial = &absence
+		}
+	}
+	return partial, partial != nil
+}
+
+func (t *TeamworkAPI) isAbsentAllDay(date string) bool {
+	absence, found := t.FindAbsence(date)
+	return found && absence.IsFullDay()
+}
+
+func (t *TeamworkAPI) absenceAdjusted(date string, minutos int) int {
+	restante := minutos
+	for _, absence := range t.currentAbsences() {
+		if !absence.Contains(date) {
+			continue
+		}
+
+		switch {
+		case absence.IsFullDay():
+			return 0
+		case absence.IsHalfDay():
+			restante -= minutos / 2
+		default:
+			restante -= absence.Minutes
+		}
+	}
+
+	if restante < 0 {
+		return 0
+	}
+	return restante
+}
+
+func scaleMinutes(minutes int, fraction float64) int {
+	if fraction >= 1 {
+		return minutes
+	}
+	return int(float64(minutes)*fraction + 0.5)
+}
+
+func (t *TeamworkAPI) planDayStart(date string) int {
+	inicio := t.dayStartMinutes()
+
+	absence, found := t.FindAbsence(date)
+	if !found || absence.Period != "morning" {
+		return inicio
+	}
+
+	if _, fimAlmoco, ok := t.lunchWindow(); ok && fimAlmoco > inicio {
+		return fimAlmoco
+	}
+	return inicio + t.Config.MinutosPorDia/2
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultActivityDays     = 14
+	maxActivityDays         = 90
+	defaultActivityPageSize = 20
+	dashboardActivityLimit  = 5
+)
+
+type ActivityItem struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Timestamp   string `json:"timestamp"`
+	Date        string `json:"date"`
+	Minutes     int    `json:"minutes,omitempty"`
+	ProjectID   int    `json:"projectId,omitempty"`
+	ProjectName string `json:"projectName,omitempty"`
+	TaskID      int    `json:"taskId,omitempty"`
+	TaskName    string `json:"taskName,omitempty"`
+	EntryID     int    `json:"entryId,omitempty"`
+	Link        string `json:"link,omitempty"`
+}
+
+type ActivityFeed struct {
+	Items    []ActivityItem `json:"items"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"pageSize"`
+	Total    int            `json:"total"`
+	HasMore  bool           `json:"hasMore"`
+}
+
+func (t *TeamworkAPI) GetActivityFeed(days, page, pageSize int) (*ActivityFeed, error) {
+	if days <= 0 {
+		days = defaultActivityDays
+	}
+	if days > maxActivityDays {
+		return nil, fmt.Errorf("período de atividades muito longo: %d dias (máximo %d)", days, maxActivityDays)
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultActivityPageSize
+	}
+
+	items, err := t.collectActivities(days)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &ActivityFeed{
+		Items:    []ActivityItem{},
+		Page:     page,
+		PageSize: pageSize,
+		Total:    len(items),
+	}
+
+	inicio := (page - 1) * pageSize
+	if inicio < len(items) {
+		fim := minValue(inicio+pageSize, len(items))
+		feed.Items = items[inicio:fim]
+		feed.HasMore = fim < len(items)
+	}
+
+	return feed, nil
+}
+
+func (t *TeamworkAPI) collectActivities(days int) ([]ActivityItem, error) {
+	cacheKey := fmt.Sprintf("activity_feed_%d", days)
+	if cachedData, found := t.cache.Get(cacheKey); found {
+		return cachedData.([]ActivityItem), nil
+	}
+
+	if !t.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+
+	agora := time.Now()
+	desde := dateOnly(agora).AddDate(0, 0, -days)
+
+	entries, err := t.getChangedTimeEntries(desde)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]ActivityItem, 0, len(entries))
+	for _, entry := range entries {
+		item := t.timeEntryActivity(entry)
+		if timestamp := activityTimestamp(item.Timestamp); timestamp.Before(desde) {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	tasks, err := t.QueryAllTasks(TaskQuery{Status: "all", UpdatedAfter: formatDate(desde)})
+	if err != nil {
+		t.logDebug("Erro ao obter alterações de tarefas para o feed de atividades: %v", err)
+	} else {
+		for _, task := range tasks {
+			if item, ok := t.taskActivity(task, desde); ok {
+				items = append(items, item)
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Timestamp != items[j].Timestamp {
+			return items[i].Timestamp > items[j].Timestamp
+		}
+		return items[i].ID > items[j].ID
+	})
+
+	t.cache.Set(cacheKey, items, 5*time.Minute)
+	return items, nil
+}
+
+func (t *TeamworkAPI) getChangedTimeEntries(desde time.Time) ([]TimeEntryReport, error) {
+	filter := "updatedAfter=" + url.QueryEscape(desde.UTC().Format(time.RFC3339)) + "&showDeleted=1"
+
+	var entries []TimeEntryReport
+	for page := 1; page <= timeEntriesV2MaxPages; page++ {
+		pageEntries, rows, err := t.getTimeEntriesPageV2(filter, page)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, pageEntries...)
+		if rows < timeEntriesV2PageSize {
+			return entries, nil
+		}
+	}
+
+	return nil, fmt.Errorf("atividades demais no período: mais de %d páginas de %d lançamentos", timeEntriesV2MaxPages, timeEntriesV2PageSize)
+}
+
+func (t *TeamworkAPI) invalidateActivityFeed() {
+	t.cache.DeletePrefix("activity_feed_")
+}
+
+func (t *TeamworkAPI) timeEntryActivity(entry TimeEntryReport) ActivityItem {
+	item := ActivityItem{
+		ID:          fmt.Sprintf("time-%d", entry.ID),
+		Type:        "time_logged",
+		Minutes:     entry.Minutes,
+		ProjectID:   entry.ProjectID,
+		ProjectName: entry.ProjectName,
+		TaskID:      entry.TaskID,
+		TaskName:    entry.TaskName,
+		EntryID:     entry.ID,
+	}
+
+	alvo := entry.TaskName
+	if alvo == "" {
+		alvo = entry.ProjectName
+	}
+
+	quando := entry.CreatedAt
+	switch {
+	case entry.DeletedAt != "":
+		item.Type = "time_deleted"
+		item.Description = fmt.Sprintf("Tempo removido: %s em %s", formatMinutesAsHours(entry.Minutes), alvo)
+		quando = entry.DeletedAt
+	case entry.UpdatedAt != "" && activityTimestamp(entry.UpdatedAt).Sub(activityTimestamp(entry.CreatedAt)) > time.Minute:
+		item.Type = "time_updated"
+		item.Description = fmt.Sprintf("Tempo alterado: %s em %s", formatMinutesAsHours(entry.Minutes), alvo)
+		quando = entry.UpdatedAt
+	default:
+		item.Description = fmt.Sprintf("Tempo registrado: %s em %s", formatMinutesAsHours(entry.Minutes), alvo)
+	}
+
+	if strings.TrimSpace(entry.Description) != "" {
+		item.Description += " - " + strings.TrimSpace(entry.Description)
+	}
+
+	timestamp := activityTimestamp(quando)
+	if timestamp.IsZero() {
+		timestamp, _ = time.ParseInLocation("2006-01-02", entry.Date, time.Local)
+	}
+	item.Timestamp = timestamp.UTC().Format(time.RFC3339)
+	item.Date = entry.Date
+
+	if entry.TaskID > 0 {
+		item.Link = t.webLink(fmt.Sprintf("/app/tasks/%d", entry.TaskID))
+	} else if entry.ProjectID > 0 {
+		item.Link = t.webLink(fmt.Sprintf("/app/projects/%d/time", entry.ProjectID))
+	}
+
+	return item
+}
+
+func (t *TeamworkAPI) taskActivity(task TeamworkTask, desde time.Time) (ActivityItem, bool) {
+	updatedAt := activityTimestamp(task.UpdatedAt)
+	createdAt := activityTimestamp(task.CreatedAt)
+	if updatedAt.IsZero() {
+		updatedAt = createdAt
+	}
+	if updatedAt.IsZero() || updatedAt.Before(desde) {
+		return ActivityItem{}, false
+	}
+
+	nome := task.Content
+	if nome == "" {
+		nome = task.Name
+	}
+
+	item := ActivityItem{
+		ID:          fmt.Sprintf("task-%d", task.ID),
+		Type:        "task_updated",
+		Description: "Tarefa atualizada: " + nome,
+		Timestamp:   updatedAt.UTC().Format(time.RFC3339),
+		Date:        formatDate(updatedAt.Local()),
+		ProjectID:   task.ProjectID,
+		ProjectName: task.ProjectName,
+		TaskID:      task.ID,
+		TaskName:    nome,
+		Link:        t.webLink(fmt.Sprintf("/app/tasks/%d", task.ID)),
+	}
+
+	switch {
+	case task.Status == "completed":
+		item.Type = "task_completed"
+		item.Description = "Tarefa concluída: " + nome
+	case !createdAt.IsZero() && updatedAt.Sub(createdAt) < time.Minute:
+		item.Type = "task_created"
+		item.Description = "Tarefa criada: " + nome
+	}
+
+	return item, true
+}
+
+func (t *TeamworkAPI) webLink(path string) string {
+	if t.Config.ApiHost == "" {
+		return ""
+	}
+	return t.buildURL(path)
+}
+
+func activityTimestamp(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+func (t *TeamworkAPI) GetRecentActivities() ([]map[string]interface{}, error) {
+	feed, err := t.GetActivityFeed(defaultActivityDays, 1, dashboardActivityLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	atividades := make([]map[string]interface{}, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		atividades = append(atividades, map[string]interface{}{
+			"id":          item.ID,
+			"type":        item.Type,
+			"description": item.Description,
+			"minutes":     item.Minutes,
+			"date":        item.Date,
+			"timestamp":   item.Timestamp,
+			"projectId":   item.ProjectID,
+			"projectName": item.ProjectName,
+			"taskId":      item.TaskID,
+			"taskName":    item.TaskName,
+			"link":        item.Link,
+		})
+	}
+
+	return atividades, nil
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type TaskBudget struct {
+	TaskID           int     `json:"taskId"`
+	TaskName         string  `json:"taskName"`
+	ProjectID        int     `json:"projectId"`
+	ProjectName      string  `json:"projectName"`
+	TasklistID       int     `json:"tasklistId,omitempty"`
+	TasklistName     string  `json:"tasklistName,omitempty"`
+	Status           string  `json:"status,omitempty"`
+	HasEstimate      bool    `json:"hasEstimate"`
+	EstimatedMinutes int     `json:"estimatedMinutes"`
+	LoggedMinutes    int     `json:"loggedMinutes"`
+	RemainingMinutes int     `json:"remainingMinutes"`
+	OverMinutes      int     `json:"overMinutes,omitempty"`
+	PercentUsed      float64 `json:"percentUsed"`
+	OverEstimate     bool    `json:"overEstimate"`
+}
+
+type TasklistBudget struct {
+	TasklistID           int          `json:"tasklistId"`
+	TasklistName         string       `json:"tasklistName"`
+	ProjectID            int          `json:"projectId"`
+	ProjectName          string       `json:"projectName"`
+	EstimatedMinutes     int          `json:"estimatedMinutes"`
+	LoggedMinutes        int          `json:"loggedMinutes"`
+	RemainingMinutes     int          `json:"remainingMinutes"`
+	OverMinutes          int          `json:"overMinutes,omitempty"`
+	PercentUsed          float64      `json:"percentUsed"`
+	TasksWithoutEstimate int          `json:"tasksWithoutEstimate"`
+	TasksOverEstimate    int          `json:"tasksOverEstimate"`
+	Tasks                []TaskBudget `json:"tasks"`
+}
+
+func newTaskBudget(task TeamworkTask) TaskBudget {
+	nome := task.Content
+	if nome == "" {
+		nome = task.Name
+	}
+
+	budget := TaskBudget{
+		TaskID:           task.ID,
+		TaskName:         nome,
+		ProjectID:        task.ProjectID,
+		ProjectName:      task.ProjectName,
+		TasklistID:       task.TasklistID,
+		TasklistName:     task.TasklistName,
+		Status:           task.Status,
+		HasEstimate:      task.EstimatedMinutes > 0,
+		EstimatedMinutes: task.EstimatedMinutes,
+		LoggedMinutes:    task.LoggedMinutes,
+	}
+
+	budget.RemainingMinutes, budget.OverMinutes, budget.PercentUsed = burnDown(budget.EstimatedMinutes, budget.LoggedMinutes)
+	budget.OverEstimate = budget.HasEstimate && budget.OverMinutes > 0
+	return budget
+}
+
+func burnDown(estimado, lancado int) (restante, excedente int, percentual float64) {
+	if estimado <= 0 {
+		return 0, 0, 0
+	}
+
+	if lancado > estimado {
+		excedente = lancado - estimado
+	} else {
+		restante = estimado - lancado
+	}
+	percentual = math.Round(float64(lancado)/float64(estimado)*1000) / 10
+	return restante, excedente, percentual
+}
+
+func (t *TeamworkAPI) GetTaskBudget(taskID int) (*TaskBudget, error) {
+	cacheKey := fmt.Sprintf("task_budget_%d", taskID)
+	if cachedData, found := t.cache.Get(cacheKey); found {
+		return cachedData.(*TaskBudget), nil
+	}
+
+	task, err := t.GetTaskDetails(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := newTaskBudget(task)
+	t.cache.Set(cacheKey, &budget, 5*time.Minute)
+	return &budget, nil
+}
+
+func (t *TeamworkAPI) GetTasklistBudget(tasklistID int) (*TasklistBudget, error) {
+	cacheKey := fmt.Sprintf("tasklist_budget_%d", tasklistID)
+	if cachedData, found := t.cache.Get(cacheKey); found {
+		return cachedData.(*TasklistBudget), nil
+	}
+
+	tasks, err := t.queryAllTasks(TaskQuery{
+		AnyAssignee:     true,
+		TasklistIDs:     []int{tasklistID},
+		Status:          "all",
+		IncludeSubtasks: true,
+		IncludeTime:     true,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &TasklistBudget{
+		TasklistID: tasklistID,
+		Tasks:      make([]TaskBudget, 0, len(tasks)),
+	}
+
+	for _, task := range tasks {
+		taskBudget := newTaskBudget(task)
+		budget.Tasks = append(budget.Tasks, taskBudget)
+
+		if budget.TasklistName == "" {
+			budget.TasklistName = task.TasklistName
+			budget.ProjectID = task.ProjectID
+			budget.ProjectName = task.ProjectName
+		}
+
+		budget.LoggedMinutes += taskBudget.LoggedMinutes
+		if !taskBudget.HasEstimate {
+			budget.TasksWithoutEstimate++
+			continue
+		}
+		budget.EstimatedMinutes += taskBudget.EstimatedMinutes
+		if taskBudget.OverEstimate {
+			budget.TasksOverEstimate++
+		}
+	}
+
+	budget.RemainingMinutes, budget.OverMinutes, budget.PercentUsed = burnDown(budget.EstimatedMinutes, budget.LoggedMinutes)
+
+	sort.Slice(budget.Tasks, func(i, j int) bool {
+		if budget.Tasks[i].PercentUsed != budget.Tasks[j].PercentUsed {
+			return budget.Tasks[i].PercentUsed > budget.Tasks[j].PercentUsed
+		}
+		return budget.Tasks[i].TaskID < budget.Tasks[j].TaskID
+	})
+
+	t.cache.Set(cacheKey, budget, 5*time.Minute)
+	return budget, nil
+}
+
+func (t *TeamworkAPI) invalidateBudgets() {
+	t.cache.DeletePrefix("task_budget_")
+	t.cache.DeletePrefix("tasklist_budget_")
+}
+
+func (t *TeamworkAPI) AnnotatePlanBudgets(plan []WorkDay) []WorkDay {
+	if !t.IsConfigured() {
+		return plan
+	}
+
+	budgets := make(map[int]*TaskBudget)
+	for _, day := range plan {
+		for _, entry := range day.Entries {
+			if entry.TaskID <= 0 {
+				continue
+			}
+			if _, found := budgets[entry.TaskID]; found {
+				continue
+			}
+
+			budget, err := t.GetTaskBudget(entry.TaskID)
+			if err != nil {
+				t.logDebug("Erro ao obter estimativa da tarefa %d: %v", entry.TaskID, err)
+			}
+			budgets[entry.TaskID] = budget
+		}
+	}
+
+	planejado := make(map[int]int)
+	for i := range plan {
+		for j := range plan[i].Entries {
+			entry := &plan[i].Entries[j]
+			budget := budgets[entry.TaskID]
+			if budget == nil || !budget.HasEstimate {
+				continue
+			}
+
+			planejado[entry.TaskID] += entry.Entry.Minutes
+			total := budget.LoggedMinutes + planejado[entry.TaskID]
+			if total > budget.EstimatedMinutes {
+				entry.Warning = fmt.Sprintf("Ultrapassa a estimativa da tarefa em %s (%s lançadas + %s planejadas de %s estimadas)",
+					formatMinutesAsHours(total-budget.EstimatedMinutes),
+					formatMinutesAsHours(budget.LoggedMinutes),
+					formatMinutesAsHours(planejado[entry.TaskID]),
+					formatMinutesAsHours(budget.EstimatedMinutes))
+			}
+		}
+	}
+
+	return plan
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"strings"
 	"sync"
 	"time"
 )
@@ -54,6 +55,17 @@ func (c *Cache) Delete(key string) {
 	delete(c.data, key)
 }
 
+func (c *Cache) DeletePrefix(prefix string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key := range c.data {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.data, key)
+		}
+	}
+}
+
 func (c *Cache) Clear() {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -0,0 +1,262 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ComplianceOptions struct {
+	LargeEntryMinutes  int   `json:"largeEntryMinutes,omitempty"`
+	ToleranceMinutes   int   `json:"toleranceMinutes,omitempty"`
+	BillableProjectIDs []int `json:"billableProjectIds,omitempty"`
+}
+
+type ComplianceFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	EntryID  int    `json:"entryId,omitempty"`
+	Minutes  int    `json:"minutes,omitempty"`
+}
+
+type ComplianceDay struct {
+	Date            string              `json:"date"`
+	Weekday         string              `json:"weekday"`
+	IsWorkDay       bool                `json:"isWorkDay"`
+	ExpectedMinutes int                 `json:"expectedMinutes"`
+	LoggedMinutes   int                 `json:"loggedMinutes"`
+	Findings        []ComplianceFinding `json:"findings"`
+}
+
+type ComplianceReport struct {
+	Year            int             `json:"year"`
+	Month           int             `json:"month"`
+	Score           int             `json:"score"`
+	Days            []ComplianceDay `json:"days"`
+	Errors          int             `json:"errors"`
+	Warnings        int             `json:"warnings"`
+	Infos           int             `json:"infos"`
+	CheckedDays     int             `json:"checkedDays"`
+	CompliantDays   int             `json:"compliantDays"`
+	ExpectedMinutes int             `json:"expectedMinutes"`
+	LoggedMinutes   int             `json:"loggedMinutes"`
+}
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+var compliancePenalty = map[string]int{
+	SeverityError:   10,
+	SeverityWarning: 3,
+	SeverityInfo:    1,
+}
+
+func (t *TeamworkAPI) GetComplianceReport(year, month int, options ComplianceOptions) (*ComplianceReport, error) {
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("mês inválido: %d", month)
+	}
+
+	if !t.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+
+	primeiroDia := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	ultimoDia := primeiroDia.AddDate(0, 1, -1)
+	startDate := formatDate(primeiroDia)
+	endDate := formatDate(ultimoDia)
+
+	entries, err := t.GetTimeEntriesForPeriodV2(startDate, endDate, false)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter entradas de tempo: %v", err)
+	}
+
+	workingDays, err := t.GetWorkingDays(startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter dias úteis: %v", err)
+	}
+
+	nonWorkingDays, err := t.GetAllNonWorkingDays(year, month)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter dias não úteis: %v", err)
+	}
+
+	return t.evaluateCompliance(primeiroDia, ultimoDia, time.Now(), entries, workingDays, nonWorkingDays, options), nil
+}
+
+func (t *TeamworkAPI) evaluateCompliance(primeiroDia, ultimoDia, agora time.Time, entries []TimeEntryReport,
+	workingDays []string, nonWorkingDays []map[string]interface{}, options ComplianceOptions) *ComplianceReport {
+
+	hoje := formatDate(agora)
+
+	workDaySet := make(map[string]bool, len(workingDays))
+	for _, day := range workingDays {
+		workDaySet[day] = true
+	}
+
+	type nonWorkingInfo struct {
+		kind       string
+		name       string
+		isOptional bool
+	}
+	nonWorking := make(map[string]nonWorkingInfo)
+	for _, day := range nonWorkingDays {
+		date, _ := day["date"].(string)
+		info := nonWorkingInfo{}
+		info.kind, _ = day["type"].(string)
+		info.name, _ = day["name"].(string)
+		info.isOptional, _ = day["isOptional"].(bool)
+		if existing, ok := nonWorking[date]; ok && existing.kind == "holiday" {
+			continue
+		}
+		nonWorking[date] = info
+	}
+
+	billableProjects := make(map[int]bool)
+	for _, projectID := range options.BillableProjectIDs {
+		billableProjects[projectID] = true
+	}
+	if len(options.BillableProjectIDs) == 0 {
+		for _, entry := range entries {
+			if entry.IsBillable {
+				billableProjects[entry.ProjectID] = true
+			}
+		}
+	}
+
+	entriesByDay := make(map[string][]TimeEntryReport)
+	for _, entry := range entries {
+		entriesByDay[entry.Date] = append(entriesByDay[entry.Date], entry)
+	}
+
+	report := &ComplianceReport{
+		Year:  primeiroDia.Year(),
+		Month: int(primeiroDia.Month()),
+		Days:  []ComplianceDay{},
+	}
+
+	for dia := primeiroDia; !dia.After(ultimoDia); dia = dia.AddDate(0, 0, 1) {
+		data := formatDate(dia)
+		dayEntries := entriesByDay[data]
+
+		day := ComplianceDay{
+			Date:      data,
+			Weekday:   weekdayNames[dia.Weekday()],
+			IsWorkDay: workDaySet[data],
+			Findings:  []ComplianceFinding{},
+		}
+
+		for _, entry := range dayEntries {
+			day.LoggedMinutes += entry.Minutes
+		}
+
+		if day.IsWorkDay {
+			day.ExpectedMinutes = t.expectedMinutes(dia)
+		}
+
+		if data > hoje && len(dayEntries) == 0 {
+			continue
+		}
+
+		if day.IsWorkDay && data <= hoje {
+			faltante := day.ExpectedMinutes - day.LoggedMinutes
+			switch {
+			case day.LoggedMinutes == 0:
+				day.addFinding("under_minimum", SeverityError, "Nenhum lançamento em dia útil", 0, faltante)
+			case faltante > options.ToleranceMinutes:
+				day.addFinding("under_minimum", SeverityWarning,
+					fmt.Sprintf("Faltam %s para completar %s", formatMinutesAsHours(faltante), formatMinutesAsHours(day.ExpectedMinutes)), 0, faltante)
+			}
+		}
+
+		if info, ok := nonWorking[data]; ok && len(dayEntries) > 0 {
+			if info.kind == "holiday" {
+				severity := SeverityWarning
+				if info.isOptional {
+					severity = SeverityInfo
+				}
+				day.addFinding("holiday_entry", severity,
+					fmt.Sprintf("Lançamentos no feriado %s", info.name), 0, day.LoggedMinutes)
+			} else if info.kind == "absence" {
+				day.addFinding("absence_entry", SeverityWarning,
+					fmt.Sprintf("Lançamentos durante ausência (%s)", info.name), 0, day.LoggedMinutes)
+			} else {
+				message := "Lançamentos em fim de semana"
+				if dia.Weekday() != time.Saturday && dia.Weekday() != time.Sunday {
+					message = "Lançamentos em dia fora da jornada semanal"
+				}
+				day.addFinding("weekend_entry", SeverityWarning, message, 0, day.LoggedMinutes)
+			}
+		}
+
+		largeEntry := options.LargeEntryMinutes
+		if largeEntry <= 0 {
+			base := day.ExpectedMinutes
+			if base <= 0 {
+				base = t.Config.MinutosPorDia
+			}
+			largeEntry = base * 3 / 4
+		}
+
+		if len(dayEntries) == 1 && dayEntries[0].Minutes >= largeEntry {
+			day.addFinding("single_large_entry", SeverityInfo,
+				fmt.Sprintf("Dia com um único lançamento de %s", formatMinutesAsHours(dayEntries[0].Minutes)),
+				dayEntries[0].ID, dayEntries[0].Minutes)
+		}
+
+		for _, entry := range dayEntries {
+			if strings.TrimSpace(entry.Description) == "" {
+				day.addFinding("missing_description", SeverityWarning,
+					fmt.Sprintf("Lançamento sem descrição em %s", entry.TaskName), entry.ID, entry.Minutes)
+			}
+
+			if !entry.IsBillable && billableProjects[entry.ProjectID] {
+				day.addFinding("non_billable_on_billable_project", SeverityWarning,
+					fmt.Sprintf("Tempo não faturável no projeto faturável %s", entry.ProjectName), entry.ID, entry.Minutes)
+			}
+		}
+
+		report.CheckedDays++
+		report.ExpectedMinutes += day.ExpectedMinutes
+		report.LoggedMinutes += day.LoggedMinutes
+
+		if len(day.Findings) == 0 {
+			report.CompliantDays++
+			continue
+		}
+
+		for _, finding := range day.Findings {
+			switch finding.Severity {
+			case SeverityError:
+				report.Errors++
+			case SeverityWarning:
+				report.Warnings++
+			default:
+				report.Infos++
+			}
+		}
+
+		report.Days = append(report.Days, day)
+	}
+
+	report.Score = 100 - report.Errors*compliancePenalty[SeverityError] -
+		report.Warnings*compliancePenalty[SeverityWarning] - report.Infos*compliancePenalty[SeverityInfo]
+	if report.Score < 0 {
+		report.Score = 0
+	}
+
+	return report
+}
+
+func (d *ComplianceDay) addFinding(rule, severity, message string, entryID, minutes int) {
+	d.Findings = append(d.Findings, ComplianceFinding{
+		Rule:     rule,
+		Severity: severity,
+		Message:  message,
+		EntryID:  entryID,
+		Minutes:  minutes,
+	})
+}
@@ -0,0 +1,580 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type CSVColumnMapping struct {
+	Date        string `json:"date"`
+	TaskID      string `json:"taskId"`
+	TaskName    string `json:"taskName"`
+	StartTime   string `json:"startTime"`
+	Duration    string `json:"duration"`
+	Description string `json:"description"`
+	Billable    string `json:"billable"`
+}
+
+type CSVImportOptions struct {
+	Delimiter    string           `json:"delimiter"`
+	HasHeader    bool             `json:"hasHeader"`
+	DateFormat   string           `json:"dateFormat"`
+	DecimalComma bool             `json:"decimalComma"`
+	DurationUnit string           `json:"durationUnit"`
+	Columns      CSVColumnMapping `json:"columns"`
+	ProjectIDs   []int            `json:"projectIds,omitempty"`
+}
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	WorkDays     []WorkDay        `json:"workDays"`
+	Errors       []ImportRowError `json:"errors"`
+	TotalRows    int              `json:"totalRows"`
+	ImportedRows int              `json:"importedRows"`
+	TotalMinutes int              `json:"totalMinutes"`
+}
+
+var csvColumnAliases = map[string][]string{
+	"date":        {"data", "date", "dia"},
+	"taskId":      {"id tarefa", "tarefa id", "task id", "taskid", "id da tarefa"},
+	"taskName":    {"tarefa", "task", "task name", "nome da tarefa"},
+	"startTime":   {"inicio", "hora inicio", "hora", "start", "start time"},
+	"duration":    {"duracao", "minutos", "minutes", "horas", "hours", "duration", "tempo"},
+	"description": {"descricao", "description", "atividade", "observacao"},
+	"billable":    {"faturavel", "cobravel", "billable", "faturado"},
+}
+
+var thousandsPattern = regexp.MustCompile(`^\d{1,3}(\.\d{3})+(,\d+)?$`)
+
+var durationPattern = regexp.MustCompile(`^(?:(\d+(?:[.,]\d+)?)h)?(?:(\d+)(?:m|min)?)?$`)
+
+func (t *TeamworkAPI) ImportTimesheetCSV(content string, options CSVImportOptions) (*ImportResult, error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("arquivo CSV vazio")
+	}
+
+	delimiter := options.Delimiter
+	if delimiter == "" {
+		delimiter = ","
+		if options.DecimalComma {
+			delimiter = ";"
+		}
+	}
+	if delimiter == "\\t" {
+		delimiter = "\t"
+	}
+
+	comma, size := utf8.DecodeRuneInString(delimiter)
+	if size != len(delimiter) {
+		return nil, fmt.Errorf("delimitador inválido: %q", options.Delimiter)
+	}
+
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var header []string
+	if options.HasHeader {
+		record, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler cabeçalho do CSV: %v", err)
+		}
+		header = record
+	}
+
+	columns, err := resolveCSVColumns(options.Columns, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := columns["date"]; !ok {
+		return nil, fmt.Errorf("coluna de data não encontrada no CSV")
+	}
+	if _, ok := columns["duration"]; !ok {
+		return nil, fmt.Errorf("coluna de duração não encontrada no CSV")
+	}
+
+	durationUnit := options.DurationUnit
+	switch durationUnit {
+	case "hours", "minutes":
+	case "":
+		if idx := columns["duration"]; idx < len(header) {
+			durationUnit = inferDurationUnit(header[idx])
+		}
+	default:
+		return nil, fmt.Errorf("unidade de duração inválida: %s (use hours ou minutes)", options.DurationUnit)
+	}
+	if durationUnit == "" {
+		durationUnit = "minutes"
+	}
+
+	_, hasTaskID := columns["taskId"]
+	_, hasTaskName := columns["taskName"]
+	if !hasTaskID && !hasTaskName && len(t.currentMappingRules()) == 0 {
+		return nil, fmt.Errorf("coluna de tarefa (ID ou nome) não encontrada no CSV")
+	}
+
+	rules, err := compileMappingRules(t.currentMappingRules())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		WorkDays: []WorkDay{},
+		Errors:   []ImportRowError{},
+	}
+
+	resolver := &taskNameResolver{api: t, projectIDs: options.ProjectIDs}
+	workDays := make(map[string]*WorkDay)
+	row := 0
+	if options.HasHeader {
+		row = 1
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row++
+
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Row: row, Message: fmt.Sprintf("linha inválida: %v", err)})
+			continue
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+		result.TotalRows++
+
+		field := func(name string) string {
+			idx, ok := columns[name]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		rowErrors := len(result.Errors)
+		addError := func(column, value, message string) {
+			result.Errors = append(result.Errors, ImportRowError{Row: row, Column: column, Value: value, Message: message})
+		}
+
+		date, err := parseImportDate(field("date"), options.DateFormat)
+		if err != nil {
+			addError("date", field("date"), err.Error())
+		}
+
+		minutes, err := parseDurationText(field("duration"), options.DecimalComma, durationUnit)
+		if err != nil {
+			addError("duration", field("duration"), err.Error())
+		} else if minutes <= 0 {
+			addError("duration", field("duration"), "a duração deve ser maior que zero")
+		}
+
+		startTime := ""
+		if value := field("startTime"); value != "" {
+			start, err := parseImportClock(value)
+			if err != nil {
+				addError("startTime", value, err.Error())
+			} else {
+				startTime = formatClock(start)
+			}
+		}
+
+		billable := true
+		if value := field("billable"); value != "" {
+			billable, err = parseBillable(value)
+			if err != nil {
+				addError("billable", value, err.Error())
+			}
+		}
+
+		description := field("description")
+		taskID := 0
+		if value := field("taskId"); value != "" {
+			taskID, err = strconv.Atoi(strings.TrimPrefix(value, "#"))
+			if err != nil || taskID <= 0 {
+				addError("taskId", value, "ID de tarefa inválido")
+			}
+		} else if value := field("taskName"); value != "" {
+			taskID, err = resolver.resolve(value)
+			if err != nil {
+				addError("taskName", value, err.Error())
+			}
+		} else if match := matchCompiledRules(rules, MappingInput{Description: description}); match.Matched {
+			taskID = match.TaskID
+			if field("billable") == "" {
+				billable = match.IsBillable
+			}
+			if match.Description != "" {
+				description = match.Description
+			}
+		} else {
+			addError("taskId", "", "tarefa não informada")
+		}
+
+		if len(result.Errors) > rowErrors {
+			continue
+		}
+
+		dateStr := formatDate(date)
+		workDay, ok := workDays[dateStr]
+		if !ok {
+			workDay = &WorkDay{Date: dateStr, Entries: []EntryTask{}}
+			workDays[dateStr] = workDay
+		}
+
+		planEntry := t.importPlanEntry(taskID, TimeEntry{
+			Minutes:     minutes,
+			Time:        startTime,
+			Description: description,
+			IsBillable:  billable,
+			Date:        dateStr,
+		})
+		workDay.Entries = append(workDay.Entries, planEntry)
+		workDay.TotalMin += planEntry.Entry.Minutes
+		result.ImportedRows++
+		result.TotalMinutes += planEntry.Entry.Minutes
+	}
+
+	for _, workDay := range workDays {
+		t.fillMissingStartTimes(workDay)
+		result.WorkDays = append(result.WorkDays, *workDay)
+	}
+
+	sort.Slice(result.WorkDays, func(i, j int) bool {
+		return result.WorkDays[i].Date < result.WorkDays[j].Date
+	})
+
+	return result, nil
+}
+
+func (t *TeamworkAPI) fillMissingStartTimes(workDay *WorkDay) {
+	semHorario := true
+	for _, alocacao := range workDay.Entries {
+		if alocacao.Entry.Time != "" {
+			semHorario = false
+			break
+		}
+	}
+
+	if semHorario && t.Config.Schedule.Enabled {
+		t.layoutWorkDay(workDay, t.dayStartMinutes())
+		return
+	}
+
+	for i := range workDay.Entries {
+		if workDay.Entries[i].Entry.Time == "" {
+			workDay.Entries[i].Entry.Time = formatClock(t.dayStartMinutes())
+		}
+	}
+}
+
+func resolveCSVColumns(mapping CSVColumnMapping, header []string) (map[string]int, error) {
+	configured := map[string]string{
+		"date":        mapping.Date,
+		"taskId":      mapping.TaskID,
+		"taskName":    mapping.TaskName,
+		"startTime":   mapping.StartTime,
+		"duration":    mapping.Duration,
+		"description": mapping.Description,
+		"billable":    mapping.Billable,
+	}
+
+	normalizedHeader := make([]string, len(header))
+	for i, name := range header {
+		normalizedHeader[i] = normalizeText(name)
+	}
+
+	findHeader := func(name string) int {
+		target := normalizeText(name)
+		for i, h := range normalizedHeader {
+			if h == target {
+				return i
+			}
+		}
+		return -1
+	}
+
+	columns := make(map[string]int)
+	for field, value := range configured {
+		value = strings.TrimSpace(value)
+
+		if value == "" {
+			for _, alias := range csvColumnAliases[field] {
+				if idx := findHeader(alias); idx >= 0 {
+					columns[field] = idx
+					break
+				}
+			}
+			continue
+		}
+
+		if idx := findHeader(value); idx >= 0 {
+			columns[field] = idx
+			continue
+		}
+
+		idx, err := strconv.Atoi(value)
+		if err != nil || idx < 1 {
+			return nil, fmt.Errorf("coluna '%s' não encontrada para o campo %s", value, field)
+		}
+		columns[field] = idx - 1
+	}
+
+	return columns, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func dateLayoutFromPattern(pattern string) string {
+	if strings.Contains(pattern, "2006") {
+		return pattern
+	}
+
+	layout := strings.ToLower(pattern)
+	layout = strings.ReplaceAll(layout, "yyyy", "2006")
+	layout = strings.ReplaceAll(layout, "aaaa", "2006")
+	layout = strings.ReplaceAll(layout, "yy", "06")
+	layout = strings.ReplaceAll(layout, "mm", "01")
+	layout = strings.ReplaceAll(layout, "dd", "02")
+	return layout
+}
+
+func parseImportDate(value, pattern string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("data não informada")
+	}
+
+	layouts := []string{"02/01/2006", "2006-01-02", "02-01-2006", "02.01.2006", "2/1/2006"}
+	if pattern != "" {
+		layouts = []string{dateLayoutFromPattern(pattern)}
+	}
+
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	if pattern != "" {
+		return time.Time{}, fmt.Errorf("data fora do formato %s", pattern)
+	}
+	return time.Time{}, fmt.Errorf("data inválida")
+}
+
+func parseDurationText(value string, decimalComma bool, unit string) (int, error) {
+	text := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+	if text == "" {
+		return 0, fmt.Errorf("duração não informada")
+	}
+
+	if parts := strings.Split(text, ":"); len(parts) == 2 {
+		hours, errH := strconv.Atoi(parts[0])
+		minutes, errM := strconv.Atoi(parts[1])
+		if errH != nil || errM != nil || minutes >= 60 || hours < 0 || minutes < 0 {
+			return 0, fmt.Errorf("duração inválida")
+		}
+		return hours*60 + minutes, nil
+	}
+
+	if strings.ContainsAny(text, "hm") {
+		match := durationPattern.FindStringSubmatch(text)
+		if match == nil || (match[1] == "" && match[2] == "") {
+			return 0, fmt.Errorf("duração inválida")
+		}
+
+		total := 0.0
+		if match[1] != "" {
+			hours, err := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+			if err != nil {
+				return 0, fmt.Errorf("duração inválida")
+			}
+			total += hours * 60
+		}
+		if match[2] != "" {
+			minutes, _ := strconv.Atoi(match[2])
+			total += float64(minutes)
+		}
+		return int(math.Round(total)), nil
+	}
+
+	if decimalComma {
+		if thousandsPattern.MatchString(text) {
+			text = strings.ReplaceAll(text, ".", "")
+		}
+		text = strings.Replace(text, ",", ".", 1)
+	}
+
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("duração inválida")
+	}
+
+	if unit == "hours" {
+		return int(math.Round(number * 60)), nil
+	}
+	return int(math.Round(number)), nil
+}
+
+func parseImportClock(value string) (int, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	text = strings.TrimSuffix(text, "h")
+	text = strings.Replace(text, "h", ":", 1)
+	if !strings.Contains(text, ":") {
+		text += ":00"
+	}
+
+	minutes, err := parseClock(text)
+	if err != nil {
+		return 0, fmt.Errorf("horário inválido: %s", value)
+	}
+	return minutes, nil
+}
+
+func inferDurationUnit(header string) string {
+	nome := normalizeText(header)
+	switch {
+	case strings.Contains(nome, "hora") || strings.Contains(nome, "hour"):
+		return "hours"
+	case strings.Contains(nome, "min"):
+		return "minutes"
+	}
+	return ""
+}
+
+func parseBillable(value string) (bool, error) {
+	switch normalizeText(value) {
+	case "sim", "s", "yes", "y", "true", "1", "x", "faturavel", "cobravel":
+		return true, nil
+	case "nao", "n", "no", "false", "0", "-":
+		return false, nil
+	}
+	return false, fmt.Errorf("valor de faturável não reconhecido")
+}
+
+type taskNameResolver struct {
+	api        *TeamworkAPI
+	projectIDs []int
+	tasks      []TeamworkTask
+	loaded     bool
+	loadErr    error
+}
+
+func (r *taskNameResolver) load() error {
+	if r.loaded {
+		return r.loadErr
+	}
+	r.loaded = true
+
+	seen := make(map[int]bool)
+	add := func(tasks []TeamworkTask) {
+		for _, task := range tasks {
+			if !seen[task.ID] {
+				seen[task.ID] = true
+				r.tasks = append(r.tasks, task)
+			}
+		}
+	}
+
+	tasks, err := r.api.GetTasks()
+	if err != nil {
+		r.loadErr = fmt.Errorf("erro ao obter tarefas para resolver nomes: %v", err)
+	}
+	add(tasks)
+
+	for _, projectID := range r.projectIDs {
+		projectTasks, err := r.api.GetTasksByProject(projectID)
+		if err != nil {
+			continue
+		}
+		add(projectTasks)
+	}
+
+	if len(r.tasks) > 0 {
+		r.loadErr = nil
+	}
+
+	return r.loadErr
+}
+
+func (r *taskNameResolver) resolve(name string) (int, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(name), "#")); err == nil && id > 0 {
+		return id, nil
+	}
+
+	if err := r.load(); err != nil {
+		return 0, err
+	}
+
+	target := normalizeText(name)
+	var partial []TeamworkTask
+
+	for _, task := range r.tasks {
+		taskName := task.Content
+		if taskName == "" {
+			taskName = task.Name
+		}
+
+		normalized := normalizeText(taskName)
+		if normalized == target {
+			return task.ID, nil
+		}
+		if strings.Contains(normalized, target) {
+			partial = append(partial, task)
+		}
+	}
+
+	switch len(partial) {
+	case 0:
+		return 0, fmt.Errorf("tarefa não encontrada")
+	case 1:
+		return partial[0].ID, nil
+	}
+
+	nomes := make([]string, 0, len(partial))
+	for _, task := range partial[:m(len(partial), 3)] {
+		nomes = append(nomes, fmt.Sprintf("#%d %s", task.ID, task.Content))
+	}
+	return 0, fmt.Errorf("nome de tarefa ambíguo (%d correspondências: %s)", len(partial), strings.Join(nomes, "; "))
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+func normalizeText(value string) string {
+	text := accentReplacer.Replace(strings.ToLower(strings.TrimSpace(value)))
+	return strings.Join(strings.Fields(text), " ")
+}
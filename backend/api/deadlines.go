@@ -0,0 +1,205 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDeadlineHorizonDays = 7
+	maxDeadlineHorizonDays     = 90
+	dashboardDeadlineLimit     = 10
+)
+
+type DeadlineTask struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	DueDate       string `json:"dueDate"`
+	DaysLeft      int    `json:"daysLeft"`
+	IsOverdue     bool   `json:"isOverdue"`
+	Priority      string `json:"priority"`
+	PriorityLabel string `json:"priorityLabel"`
+	ProjectID     int    `json:"projectId"`
+	ProjectName   string `json:"projectName"`
+	TasklistName  string `json:"tasklistName,omitempty"`
+	Status        string `json:"status,omitempty"`
+}
+
+type DeadlineOverview struct {
+	HorizonDays int            `json:"horizonDays"`
+	Overdue     []DeadlineTask `json:"overdue"`
+	Upcoming    []DeadlineTask `json:"upcoming"`
+}
+
+var taskPriorityRanks = map[string]int{
+	"high":   3,
+	"medium": 2,
+	"low":    1,
+}
+
+var taskPriorityLabels = map[string]string{
+	"high":   "Alta",
+	"medium": "Média",
+	"low":    "Baixa",
+}
+
+func ValidateDeadlineHorizon(days int) error {
+	if days < 0 || days > maxDeadlineHorizonDays {
+		return fmt.Errorf("horizonte de prazos inválido: %d (use de 1 a %d dias, ou 0 para o padrão)", days, maxDeadlineHorizonDays)
+	}
+	return nil
+}
+
+func (t *TeamworkAPI) deadlineHorizon() int {
+	if t.Config.HorizontePrazos > 0 {
+		return t.Config.HorizontePrazos
+	}
+	return defaultDeadlineHorizonDays
+}
+
+func (t *TeamworkAPI) GetDeadlineOverview(horizonDays int, includeOverdue bool) (*DeadlineOverview, error) {
+	if horizonDays == 0 {
+		horizonDays = t.deadlineHorizon()
+	}
+	if err := ValidateDeadlineHorizon(horizonDays); err != nil {
+		return nil, err
+	}
+
+	cacheKey := fmt.Sprintf("deadlines_%d_%t", horizonDays, includeOverdue)
+	if cachedData, found := t.cache.Get(cacheKey); found {
+		return cachedData.(*DeadlineOverview), nil
+	}
+
+	hoje := dateOnly(time.Now())
+	limite := hoje.AddDate(0, 0, horizonDays)
+
+	query := TaskQuery{Status: "active", DueBefore: formatDate(limite)}
+	if !includeOverdue {
+		query.DueAfter = formatDate(hoje.AddDate(0, 0, -1))
+	}
+
+	tasks, err := t.QueryAllTasks(query)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &DeadlineOverview{
+		HorizonDays: horizonDays,
+		Overdue:     []DeadlineTask{},
+		Upcoming:    []DeadlineTask{},
+	}
+
+	for _, task := range tasks {
+		vencimento, ok := parseTaskDate(task.DueDate)
+		if !ok || vencimento.After(limite) {
+			continue
+		}
+
+		deadline := newDeadlineTask(task, vencimento, hoje)
+		if deadline.IsOverdue {
+			if includeOverdue {
+				overview.Overdue = append(overview.Overdue, deadline)
+			}
+			continue
+		}
+		overview.Upcoming = append(overview.Upcoming, deadline)
+	}
+
+	sortDeadlines(overview.Overdue)
+	sortDeadlines(overview.Upcoming)
+
+	t.cache.Set(cacheKey, overview, 15*time.Minute)
+	return overview, nil
+}
+
+func (t *TeamworkAPI) GetTasksWithUpcomingDeadlines() ([]map[string]interface{}, error) {
+	overview, err := t.GetDeadlineOverview(0, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tarefas := make([]map[string]interface{}, 0)
+	for _, task := range append(overview.Overdue, overview.Upcoming...) {
+		if len(tarefas) >= dashboardDeadlineLimit {
+			break
+		}
+
+		tarefas = append(tarefas, map[string]interface{}{
+			"id":            task.ID,
+			"name":          task.Name,
+			"dueDate":       task.DueDate,
+			"daysLeft":      task.DaysLeft,
+			"isOverdue":     task.IsOverdue,
+			"priority":      task.PriorityLabel,
+			"priorityValue": task.Priority,
+			"projectId":     task.ProjectID,
+			"projectName":   task.ProjectName,
+			"tasklistName":  task.TasklistName,
+		})
+	}
+
+	return tarefas, nil
+}
+
+func newDeadlineTask(task TeamworkTask, vencimento, hoje time.Time) DeadlineTask {
+	nome := task.Content
+	if nome == "" {
+		nome = task.Name
+	}
+	if nome == "" {
+		nome = fmt.Sprintf("Tarefa #%d", task.ID)
+	}
+
+	prioridade := strings.ToLower(strings.TrimSpace(task.Priority))
+	label, ok := taskPriorityLabels[prioridade]
+	if !ok {
+		label = "Normal"
+	}
+
+	diasRestantes := int(vencimento.Sub(hoje).Hours() / 24)
+	return DeadlineTask{
+		ID:            task.ID,
+		Name:          nome,
+		DueDate:       formatDate(vencimento),
+		DaysLeft:      diasRestantes,
+		IsOverdue:     diasRestantes < 0,
+		Priority:      prioridade,
+		PriorityLabel: label,
+		ProjectID:     task.ProjectID,
+		ProjectName:   task.ProjectName,
+		TasklistName:  task.TasklistName,
+		Status:        task.Status,
+	}
+}
+
+func sortDeadlines(tasks []DeadlineTask) {
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].DaysLeft != tasks[j].DaysLeft {
+			return tasks[i].DaysLeft < tasks[j].DaysLeft
+		}
+		ri, rj := taskPriorityRanks[tasks[i].Priority], taskPriorityRanks[tasks[j].Priority]
+		if ri != rj {
+			return ri > rj
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+func parseTaskDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return dateOnly(parsed), true
+	}
+	for _, layout := range []string{"2006-01-02", "20060102"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return dateOnly(parsed), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,389 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ExportOptions struct {
+	Format        string   `json:"format"`
+	Columns       []string `json:"columns"`
+	GroupBy       string   `json:"groupBy,omitempty"`
+	IncludeTotals bool     `json:"includeTotals"`
+	Delimiter     string   `json:"delimiter,omitempty"`
+	DecimalComma  bool     `json:"decimalComma"`
+}
+
+type ExportColumn struct {
+	Key   string `json:"key"`
+	Title string `json:"title"`
+}
+
+type exportColumnDef struct {
+	title   string
+	numeric bool
+	value   func(entry TimeEntryReport) interface{}
+}
+
+var exportColumnOrder = []string{
+	"date", "project", "tasklist", "task", "taskId", "description",
+	"startTime", "endTime", "minutes", "hours", "billable", "billed", "tags", "user", "id",
+}
+
+var defaultExportColumns = []string{"date", "project", "task", "description", "hours", "billable"}
+
+var exportColumns = map[string]exportColumnDef{
+	"id":          {"ID", true, func(e TimeEntryReport) interface{} { return e.ID }},
+	"date":        {"Data", false, func(e TimeEntryReport) interface{} { return e.Date }},
+	"project":     {"Projeto", false, func(e TimeEntryReport) interface{} { return e.ProjectName }},
+	"tasklist":    {"Lista de tarefas", false, func(e TimeEntryReport) interface{} { return e.TasklistName }},
+	"task":        {"Tarefa", false, func(e TimeEntryReport) interface{} { return e.TaskName }},
+	"taskId":      {"ID da tarefa", true, func(e TimeEntryReport) interface{} { return e.TaskID }},
+	"description": {"Descrição", false, func(e TimeEntryReport) interface{} { return e.Description }},
+	"startTime":   {"Início", false, func(e TimeEntryReport) interface{} { return e.StartTime }},
+	"endTime":     {"Fim", false, func(e TimeEntryReport) interface{} { return e.EndTime }},
+	"minutes":     {"Minutos", true, func(e TimeEntryReport) interface{} { return e.Minutes }},
+	"hours":       {"Horas", true, func(e TimeEntryReport) interface{} { return roundHours(e.Minutes) }},
+	"billable":    {"Faturável", false, func(e TimeEntryReport) interface{} { return e.IsBillable }},
+	"billed":      {"Faturado", false, func(e TimeEntryReport) interface{} { return e.IsBilled }},
+	"tags":        {"Tags", false, func(e TimeEntryReport) interface{} { return tagNames(e.Tags) }},
+	"user":        {"Usuário", false, func(e TimeEntryReport) interface{} { return strings.TrimSpace(e.UserFirstName + " " + e.UserLastName) }},
+}
+
+var exportGroupKeys = map[string]func(entry TimeEntryReport) string{
+	"date":     func(e TimeEntryReport) string { return e.Date },
+	"project":  func(e TimeEntryReport) string { return e.ProjectName },
+	"tasklist": func(e TimeEntryReport) string { return e.TasklistName },
+	"task":     func(e TimeEntryReport) string { return e.TaskName },
+	"billable": func(e TimeEntryReport) string { return exportText(e.IsBillable, false) },
+}
+
+type exportGroup struct {
+	Key     string
+	Entries []TimeEntryReport
+	Minutes int
+}
+
+func GetExportColumns() []ExportColumn {
+	columns := make([]ExportColumn, 0, len(exportColumnOrder))
+	for _, key := range exportColumnOrder {
+		columns = append(columns, ExportColumn{Key: key, Title: exportColumns[key].title})
+	}
+	return columns
+}
+
+func (t *TeamworkAPI) ExportTimeEntries(startDate, endDate string, options ExportOptions) (string, error) {
+	if !t.IsConfigured() {
+		return "", fmt.Errorf("API não configurada")
+	}
+
+	format := strings.ToLower(strings.TrimSpace(options.Format))
+	if format != "csv" && format != "json" && format != "xlsx" {
+		return "", fmt.Errorf("formato de exportação não suportado: %s", options.Format)
+	}
+
+	entries, err := t.GetTimeEntriesForPeriodV2(startDate, endDate, false)
+	if err != nil {
+		return "", fmt.Errorf("erro ao obter entradas de tempo: %v", err)
+	}
+
+	data, err := RenderTimeEntriesExport(entries, startDate, endDate, options)
+	if err != nil {
+		return "", err
+	}
+
+	reportsDir, err := getReportsDir()
+	if err != nil {
+		return "", err
+	}
+
+	filePath := filepath.Join(reportsDir, fmt.Sprintf("TeamworkExport_%s_%s.%s", startDate, endDate, format))
+	if err := writeFileAtomically(filePath, data); err != nil {
+		return "", err
+	}
+
+	t.logDebug("Exportação %s salva em: %s", format, filePath)
+	return filePath, nil
+}
+
+func RenderTimeEntriesExport(entries []TimeEntryReport, startDate, endDate string, options ExportOptions) ([]byte, error) {
+	columns, err := resolveExportColumns(options.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := groupExportEntries(entries, options.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(options.Format)) {
+	case "csv":
+		return renderExportCSV(columns, groups, options)
+	case "json":
+		return renderExportJSON(columns, groups, startDate, endDate, options)
+	case "xlsx":
+		return renderExportXLSX(columns, groups, options)
+	}
+
+	return nil, fmt.Errorf("formato de exportação não suportado: %s", options.Format)
+}
+
+func resolveExportColumns(keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return defaultExportColumns, nil
+	}
+
+	columns := make([]string, 0, len(keys))
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if _, ok := exportColumns[key]; !ok {
+			return nil, fmt.Errorf("coluna de exportação desconhecida: %s", key)
+		}
+		if !seen[key] {
+			seen[key] = true
+			columns = append(columns, key)
+		}
+	}
+
+	return columns, nil
+}
+
+func groupExportEntries(entries []TimeEntryReport, groupBy string) ([]exportGroup, error) {
+	ordered := make([]TimeEntryReport, len(entries))
+	copy(ordered, entries)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Date != ordered[j].Date {
+			return ordered[i].Date < ordered[j].Date
+		}
+		return ordered[i].StartTime < ordered[j].StartTime
+	})
+
+	if groupBy == "" {
+		group := exportGroup{Entries: ordered}
+		for _, entry := range ordered {
+			group.Minutes += entry.Minutes
+		}
+		return []exportGroup{group}, nil
+	}
+
+	keyFunc, ok := exportGroupKeys[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("agrupamento não suportado: %s", groupBy)
+	}
+
+	index := make(map[string]int)
+	var groups []exportGroup
+	for _, entry := range ordered {
+		key := keyFunc(entry)
+		idx, exists := index[key]
+		if !exists {
+			idx = len(groups)
+			index[key] = idx
+			groups = append(groups, exportGroup{Key: key})
+		}
+		groups[idx].Entries = append(groups[idx].Entries, entry)
+		groups[idx].Minutes += entry.Minutes
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Key < groups[j].Key
+	})
+
+	return groups, nil
+}
+
+func exportTotalsRow(columns []string, label string, minutes int, decimalComma bool) []string {
+	row := make([]string, len(columns))
+	labelPlaced := false
+
+	for i, key := range columns {
+		switch key {
+		case "minutes":
+			row[i] = exportText(minutes, decimalComma)
+		case "hours":
+			row[i] = exportText(roundHours(minutes), decimalComma)
+		default:
+			if !labelPlaced {
+				row[i] = label
+				labelPlaced = true
+			}
+		}
+	}
+
+	return row
+}
+
+func exportHeader(columns []string) []string {
+	header := make([]string, len(columns))
+	for i, key := range columns {
+		header[i] = exportColumns[key].title
+	}
+	return header
+}
+
+func exportRows(columns []string, groups []exportGroup, options ExportOptions) [][]string {
+	var rows [][]string
+	total := 0
+
+	for _, group := range groups {
+		for _, entry := range group.Entries {
+			row := make([]string, len(columns))
+			for i, key := range columns {
+				row[i] = exportText(exportColumns[key].value(entry), options.DecimalComma)
+			}
+			rows = append(rows, row)
+		}
+
+		if options.IncludeTotals && options.GroupBy != "" {
+			rows = append(rows, exportTotalsRow(columns, "Subtotal "+group.Key, group.Minutes, options.DecimalComma))
+		}
+		total += group.Minutes
+	}
+
+	if options.IncludeTotals {
+		rows = append(rows, exportTotalsRow(columns, "Total", total, options.DecimalComma))
+	}
+
+	return rows
+}
+
+func renderExportCSV(columns []string, groups []exportGroup, options ExportOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	delimiter := options.Delimiter
+	if delimiter == "" {
+		delimiter = ","
+		if options.DecimalComma {
+			delimiter = ";"
+		}
+	}
+	writer.Comma = []rune(delimiter)[0]
+
+	if err := writer.Write(exportHeader(columns)); err != nil {
+		return nil, fmt.Errorf("erro ao gerar CSV: %v", err)
+	}
+
+	if err := writer.WriteAll(exportRows(columns, groups, options)); err != nil {
+		return nil, fmt.Errorf("erro ao gerar CSV: %v", err)
+	}
+
+	return append([]byte("\ufeff"), buf.Bytes()...), nil
+}
+
+func renderExportJSON(columns []string, groups []exportGroup, startDate, endDate string, options ExportOptions) ([]byte, error) {
+	type jsonGroup struct {
+		Key          string                   `json:"key,omitempty"`
+		Entries      []map[string]interface{} `json:"entries"`
+		TotalMinutes int                      `json:"totalMinutes,omitempty"`
+		TotalHours   float64                  `json:"totalHours,omitempty"`
+	}
+
+	document := struct {
+		StartDate    string         `json:"startDate"`
+		EndDate      string         `json:"endDate"`
+		GeneratedAt  string         `json:"generatedAt"`
+		GroupBy      string         `json:"groupBy,omitempty"`
+		Columns      []ExportColumn `json:"columns"`
+		Groups       []jsonGroup    `json:"groups"`
+		TotalMinutes int            `json:"totalMinutes,omitempty"`
+		TotalHours   float64        `json:"totalHours,omitempty"`
+	}{
+		StartDate:   startDate,
+		EndDate:     endDate,
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		GroupBy:     options.GroupBy,
+		Groups:      []jsonGroup{},
+	}
+
+	for _, key := range columns {
+		document.Columns = append(document.Columns, ExportColumn{Key: key, Title: exportColumns[key].title})
+	}
+
+	total := 0
+	for _, group := range groups {
+		jg := jsonGroup{Key: group.Key, Entries: []map[string]interface{}{}}
+		for _, entry := range group.Entries {
+			item := make(map[string]interface{}, len(columns))
+			for _, key := range columns {
+				item[key] = exportColumns[key].value(entry)
+			}
+			jg.Entries = append(jg.Entries, item)
+		}
+		if options.IncludeTotals {
+			jg.TotalMinutes = group.Minutes
+			jg.TotalHours = roundHours(group.Minutes)
+		}
+		total += group.Minutes
+		document.Groups = append(document.Groups, jg)
+	}
+
+	if options.IncludeTotals {
+		document.TotalMinutes = total
+		document.TotalHours = roundHours(total)
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar JSON: %v", err)
+	}
+
+	return data, nil
+}
+
+func renderExportXLSX(columns []string, groups []exportGroup, options ExportOptions) ([]byte, error) {
+	numeric := make([]bool, len(columns))
+	for i, key := range columns {
+		numeric[i] = exportColumns[key].numeric
+	}
+
+	sheet := xlsxSheet{Header: exportHeader(columns), Numeric: numeric}
+	sheetOptions := options
+	sheetOptions.DecimalComma = false
+	sheet.Rows = exportRows(columns, groups, sheetOptions)
+
+	return sheet.render()
+}
+
+func exportText(value interface{}, decimalComma bool) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		text := strconv.FormatFloat(v, 'f', 2, 64)
+		if decimalComma {
+			text = strings.Replace(text, ".", ",", 1)
+		}
+		return text
+	case bool:
+		if v {
+			return "Sim"
+		}
+		return "Não"
+	case []string:
+		return strings.Join(v, ", ")
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func roundHours(minutes int) float64 {
+	return float64(int(float64(minutes)/60.0*100+0.5)) / 100
+}
+
+func tagNames(tags []Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
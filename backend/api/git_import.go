@@ -0,0 +1,384 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type GitImportSettings struct {
+	Repositories      []string `json:"repositories"`
+	AuthorEmail       string   `json:"authorEmail,omitempty"`
+	TaskPatterns      []string `json:"taskPatterns,omitempty"`
+	SessionGapMinutes int      `json:"sessionGapMinutes,omitempty"`
+	LeadInMinutes     int      `json:"leadInMinutes,omitempty"`
+	DefaultTaskID     int      `json:"defaultTaskId,omitempty"`
+}
+
+type gitCommit struct {
+	Hash       string
+	Repository string
+	When       time.Time
+	Ref        string
+	Subject    string
+}
+
+type gitSegment struct {
+	date       string
+	taskID     int
+	billable   bool
+	contiguous bool
+	commits    []gitCommit
+}
+
+var defaultGitTaskPatterns = []string{
+	`(?i)(?:task|tarefa|tw)[-_/#]?(\d+)`,
+}
+
+const (
+	defaultGitSessionGap = 120
+	defaultGitLeadIn     = 30
+	gitLogTimeout        = 30 * time.Second
+)
+
+func ValidateGitImportSettings(settings GitImportSettings) error {
+	for _, repository := range settings.Repositories {
+		if strings.TrimSpace(repository) == "" {
+			return fmt.Errorf("caminho de repositório vazio")
+		}
+	}
+
+	if _, err := compileGitTaskPatterns(settings.TaskPatterns); err != nil {
+		return err
+	}
+
+	if settings.SessionGapMinutes < 0 || settings.LeadInMinutes < 0 {
+		return fmt.Errorf("intervalos de sessão não podem ser negativos")
+	}
+
+	return nil
+}
+
+func compileGitTaskPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	if len(patterns) == 0 {
+		patterns = defaultGitTaskPatterns
+	}
+
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("padrão de tarefa inválido %q: %v", pattern, err)
+		}
+		if regex.NumSubexp() < 1 {
+			return nil, fmt.Errorf("padrão de tarefa %q precisa de um grupo de captura com o ID", pattern)
+		}
+		compiled = append(compiled, regex)
+	}
+	return compiled, nil
+}
+
+func (t *TeamworkAPI) ImportGitHistory(settings GitImportSettings, startDate, endDate string) (*ImportResult, error) {
+	if len(settings.Repositories) == 0 {
+		return nil, fmt.Errorf("nenhum repositório git configurado")
+	}
+
+	inicio, err := time.ParseInLocation("2006-01-02", startDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("data inicial inválida: %v", err)
+	}
+
+	fim, err := time.ParseInLocation("2006-01-02", endDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("data final inválida: %v", err)
+	}
+
+	if fim.Before(inicio) {
+		return nil, fmt.Errorf("a data final deve ser igual ou posterior à data inicial")
+	}
+
+	patterns, err := compileGitTaskPatterns(settings.TaskPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git não encontrado no PATH: %v", err)
+	}
+
+	result := &ImportResult{
+		WorkDays: []WorkDay{},
+		Errors:   []ImportRowError{},
+	}
+
+	seen := make(map[string]bool)
+	var commits []gitCommit
+	for _, repository := range settings.Repositories {
+		repoCommits, err := readGitCommits(repository, settings.AuthorEmail, inicio, fim.AddDate(0, 0, 1))
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Column: "repository", Value: repository, Message: err.Error()})
+			continue
+		}
+
+		for _, commit := range repoCommits {
+			if seen[commit.Hash] {
+				continue
+			}
+			seen[commit.Hash] = true
+			commits = append(commits, commit)
+		}
+	}
+
+	sort.Slice(commits, func(i, j int) bool {
+		return commits[i].When.Before(commits[j].When)
+	})
+
+	gap := settings.SessionGapMinutes
+	if gap == 0 {
+		gap = defaultGitSessionGap
+	}
+	leadIn := settings.LeadInMinutes
+	if leadIn == 0 {
+		leadIn = defaultGitLeadIn
+	}
+
+	rules, err := compileMappingRules(t.currentMappingRules())
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]gitSegment, 0)
+	var anterior *gitCommit
+
+	for i, commit := range commits {
+		result.TotalRows++
+
+		billable := true
+		taskID := extractGitTaskID(patterns, commit.Ref, commit.Subject)
+		if taskID == 0 {
+			input := MappingInput{Description: commit.Subject, Repository: commit.Repository, Branch: commit.Ref}
+			if match := matchCompiledRules(rules, input); match.Matched {
+				taskID, billable = match.TaskID, match.IsBillable
+			}
+		}
+		if taskID == 0 {
+			taskID = settings.DefaultTaskID
+		}
+		if taskID == 0 {
+			result.Errors = append(result.Errors, ImportRowError{
+				Row:     i + 1,
+				Column:  "taskId",
+				Value:   fmt.Sprintf("%s %s", shortHash(commit.Hash), commit.Subject),
+				Message: "nenhum ID de tarefa encontrado no branch ou na mensagem",
+			})
+			continue
+		}
+
+		date := formatDate(commit.When)
+		continua := anterior != nil && formatDate(anterior.When) == date &&
+			commit.When.Sub(anterior.When) <= time.Duration(gap)*time.Minute
+
+		last := len(segments) - 1
+		if continua && segments[last].taskID == taskID && segments[last].billable == billable {
+			segments[last].commits = append(segments[last].commits, commit)
+		} else {
+			segments = append(segments, gitSegment{
+				date:       date,
+				taskID:     taskID,
+				billable:   billable,
+				contiguous: continua,
+				commits:    []gitCommit{commit},
+			})
+		}
+
+		anterior = &commits[i]
+		result.ImportedRows++
+	}
+
+	workDays := make(map[string]*WorkDay)
+	cursor := 0
+	for i, segment := range segments {
+		if i == 0 || segments[i-1].date != segment.date {
+			cursor = 0
+		}
+
+		primeiro := segment.commits[0].When
+		ultimo := segment.commits[len(segment.commits)-1].When
+
+		inicioSessao := primeiro.Hour()*60 + primeiro.Minute() - leadIn
+		minimo := leadIn
+		if segment.contiguous {
+			previous := segments[i-1].commits[len(segments[i-1].commits)-1].When
+			inicioSessao = previous.Hour()*60 + previous.Minute()
+			minimo = 1
+		}
+		if inicioSessao < cursor {
+			inicioSessao = cursor
+		}
+
+		minutes := ultimo.Hour()*60 + ultimo.Minute() - inicioSessao
+		if minutes < minimo {
+			minutes = minimo
+		}
+		cursor = inicioSessao + minutes
+
+		workDay, ok := workDays[segment.date]
+		if !ok {
+			workDay = &WorkDay{Date: segment.date, Entries: []EntryTask{}}
+			workDays[segment.date] = workDay
+		}
+
+		planEntry := t.importPlanEntry(segment.taskID, TimeEntry{
+			Minutes:     minutes,
+			Time:        formatClock(inicioSessao),
+			Description: gitSessionDescription(segment.commits),
+			IsBillable:  segment.billable,
+			Date:        segment.date,
+		})
+		workDay.Entries = append(workDay.Entries, planEntry)
+		workDay.TotalMin += planEntry.Entry.Minutes
+		result.TotalMinutes += planEntry.Entry.Minutes
+	}
+
+	for _, workDay := range workDays {
+		sort.SliceStable(workDay.Entries, func(i, j int) bool {
+			return workDay.Entries[i].Entry.Time < workDay.Entries[j].Entry.Time
+		})
+		result.WorkDays = append(result.WorkDays, *workDay)
+	}
+
+	sort.Slice(result.WorkDays, func(i, j int) bool {
+		return result.WorkDays[i].Date < result.WorkDays[j].Date
+	})
+
+	return result, nil
+}
+
+func readGitCommits(repository, authorEmail string, since, until time.Time) ([]gitCommit, error) {
+	repository = strings.TrimSpace(repository)
+	if strings.HasPrefix(repository, "~") {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			repository = filepath.Join(homeDir, strings.TrimPrefix(repository, "~"))
+		}
+	}
+
+	if info, err := os.Stat(repository); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("repositório não encontrado")
+	}
+
+	if authorEmail == "" {
+		output, err := runGit(repository, "config", "user.email")
+		if err != nil || strings.TrimSpace(output) == "" {
+			return nil, fmt.Errorf("e-mail do autor não configurado e não encontrado no git config")
+		}
+		authorEmail = strings.TrimSpace(output)
+	}
+
+	output, err := runGit(repository, "log", "--all", "--no-merges", "--source",
+		"--author="+authorEmail,
+		"--since="+since.Format(time.RFC3339),
+		"--until="+until.Format(time.RFC3339),
+		"--pretty=format:%H%x1f%aI%x1f%S%x1f%s%x1e")
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(repository)
+	commits := make([]gitCommit, 0)
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+
+		fields := strings.Split(record, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+
+		when, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			continue
+		}
+
+		commits = append(commits, gitCommit{
+			Hash:       fields[0],
+			Repository: name,
+			When:       when.In(time.Local),
+			Ref:        strings.TrimPrefix(strings.TrimPrefix(fields[2], "refs/heads/"), "refs/remotes/"),
+			Subject:    strings.TrimSpace(fields[3]),
+		})
+	}
+
+	return commits, nil
+}
+
+func runGit(repository string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitLogTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repository}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("erro ao executar git %s: %s", args[0], message)
+	}
+
+	return stdout.String(), nil
+}
+
+func extractGitTaskID(patterns []*regexp.Regexp, sources ...string) int {
+	for _, source := range sources {
+		for _, pattern := range patterns {
+			match := pattern.FindStringSubmatch(source)
+			if match == nil {
+				continue
+			}
+			for _, group := range match[1:] {
+				if id, err := strconv.Atoi(group); err == nil && id > 0 {
+					return id
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func gitSessionDescription(session []gitCommit) string {
+	seen := make(map[string]bool)
+	summaries := make([]string, 0, len(session))
+	for _, commit := range session {
+		if commit.Subject == "" || seen[commit.Subject] {
+			continue
+		}
+		seen[commit.Subject] = true
+		summaries = append(summaries, commit.Subject)
+	}
+
+	description := fmt.Sprintf("%s: %s", session[0].Repository, strings.Join(summaries, "; "))
+	if runes := []rune(description); len(runes) > 250 {
+		description = string(runes[:247]) + "..."
+	}
+	return description
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
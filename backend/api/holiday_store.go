@@ -0,0 +1,296 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	holidayFetchTimeout       = 5 * time.Second
+	defaultHolidayRefreshDays = 30
+)
+
+type holidayStore struct {
+	Year      int       `json:"year"`
+	FetchedAt string    `json:"fetchedAt,omitempty"`
+	Remote    []Holiday `json:"remote"`
+	Imported  []Holiday `json:"imported"`
+}
+
+type HolidayImportResult struct {
+	Imported int              `json:"imported"`
+	Years    []int            `json:"years"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+func getHolidayStoreDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("erro ao obter diretório do usuário: %v", err)
+	}
+
+	return filepath.Join(homeDir, ".teamwork-logger", "holidays"), nil
+}
+
+func holidayStorePath(year int) (string, error) {
+	dir, err := getHolidayStoreDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("holidays-%d.json", year)), nil
+}
+
+func loadHolidayStore(year int) (*holidayStore, error) {
+	path, err := holidayStorePath(year)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &holidayStore{Year: year}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de feriados: %v", err)
+	}
+
+	store := &holidayStore{}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar arquivo de feriados: %v", err)
+	}
+	store.Year = year
+
+	return store, nil
+}
+
+func saveHolidayStore(store *holidayStore) error {
+	path, err := holidayStorePath(store.Year)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar feriados: %v", err)
+	}
+
+	return writeFileAtomically(path, data)
+}
+
+func (s *holidayStore) needsRefresh(refreshDays int, agora time.Time) bool {
+	if refreshDays < 0 {
+		return false
+	}
+	if refreshDays == 0 {
+		refreshDays = defaultHolidayRefreshDays
+	}
+
+	fetchedAt, err := time.Parse(time.RFC3339, s.FetchedAt)
+	if err != nil {
+		return true
+	}
+
+	return agora.Sub(fetchedAt) > time.Duration(refreshDays)*24*time.Hour
+}
+
+func holidayList(holidays map[string]Holiday) []Holiday {
+	list := make([]Holiday, 0, len(holidays))
+	for _, holiday := range holidays {
+		list = append(list, holiday)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Date < list[j].Date
+	})
+	return list
+}
+
+func holidayMap(holidays []Holiday) map[string]Holiday {
+	mapped := make(map[string]Holiday, len(holidays))
+	for _, holiday := range holidays {
+		mapped[holiday.Date] = holiday
+	}
+	return mapped
+}
+
+func (t *TeamworkAPI) ImportHolidays(content, fileName string) (*HolidayImportResult, error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("arquivo de feriados vazio")
+	}
+
+	var holidays []Holiday
+	var errors []ImportRowError
+	var err error
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext == ".ics" || strings.HasPrefix(strings.TrimSpace(content), "BEGIN:VCALENDAR") {
+		holidays, errors, err = parseHolidaysICS(content)
+	} else {
+		holidays, errors, err = parseHolidaysJSON(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HolidayImportResult{
+		Years:  []int{},
+		Errors: errors,
+	}
+
+	byYear := make(map[int][]Holiday)
+	for _, holiday := range holidays {
+		year, _ := time.Parse("2006-01-02", holiday.Date)
+		byYear[year.Year()] = append(byYear[year.Year()], holiday)
+	}
+
+	for year, imported := range byYear {
+		store, err := loadHolidayStore(year)
+		if err != nil {
+			return nil, err
+		}
+
+		merged := holidayMap(store.Imported)
+		for _, holiday := range imported {
+			merged[holiday.Date] = holiday
+		}
+		store.Imported = holidayList(merged)
+
+		if err := saveHolidayStore(store); err != nil {
+			return nil, err
+		}
+
+		invalidateHolidayCache(year)
+		result.Imported += len(imported)
+		result.Years = append(result.Years, year)
+	}
+
+	sort.Ints(result.Years)
+	return result, nil
+}
+
+func parseHolidaysJSON(content string) ([]Holiday, []ImportRowError, error) {
+	var rows []struct {
+		Date         string  `json:"date"`
+		Name         string  `json:"name"`
+		Description  string  `json:"description"`
+		Type         string  `json:"type"`
+		IsOptional   bool    `json:"isOptional"`
+		WorkFraction float64 `json:"workFraction"`
+	}
+
+	if err := json.Unmarshal([]byte(content), &rows); err != nil {
+		return nil, nil, fmt.Errorf("JSON de feriados inválido (esperado uma lista de {date, name}): %v", err)
+	}
+
+	holidays := make([]Holiday, 0, len(rows))
+	errors := make([]ImportRowError, 0)
+	for i, row := range rows {
+		date, ok := parseImportedHolidayDate(row.Date)
+		if !ok {
+			errors = append(errors, ImportRowError{Row: i + 1, Column: "date", Value: row.Date, Message: "data inválida (use AAAA-MM-DD ou DD/MM/AAAA)"})
+			continue
+		}
+
+		if row.WorkFraction < 0 || row.WorkFraction >= 1 {
+			errors = append(errors, ImportRowError{Row: i + 1, Column: "workFraction", Value: fmt.Sprintf("%.2f", row.WorkFraction), Message: "fração de jornada deve estar entre 0 e 1"})
+			continue
+		}
+
+		if strings.TrimSpace(row.Name) == "" {
+			errors = append(errors, ImportRowError{Row: i + 1, Column: "name", Value: row.Date, Message: "feriado sem nome"})
+			continue
+		}
+
+		holidayType := importedHolidayType(row.Type)
+		holidays = append(holidays, Holiday{
+			Date:         date,
+			Name:         strings.TrimSpace(row.Name),
+			Description:  row.Description,
+			Type:         holidayType,
+			IsOptional:   row.IsOptional || holidayType == "facultativo",
+			WorkFraction: row.WorkFraction,
+		})
+	}
+
+	return holidays, errors, nil
+}
+
+func parseHolidaysICS(content string) ([]Holiday, []ImportRowError, error) {
+	events, err := parseICSEvents(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	holidays := make([]Holiday, 0, len(events))
+	errors := make([]ImportRowError, 0)
+	for _, event := range events {
+		if event.Err != nil {
+			errors = append(errors, ImportRowError{Row: event.Index, Value: event.Summary, Message: event.Err.Error()})
+			continue
+		}
+
+		if strings.EqualFold(event.Status, "CANCELLED") || event.RecurrenceID != "" {
+			continue
+		}
+
+		if event.Summary == "" {
+			errors = append(errors, ImportRowError{Row: event.Index, Column: "SUMMARY", Message: "evento sem título"})
+			continue
+		}
+
+		inicio := dateOnly(event.Start)
+		fim := inicio
+		if event.AllDay && event.End.After(event.Start) {
+			fim = dateOnly(event.End).AddDate(0, 0, -1)
+		}
+
+		for dia := inicio; !dia.After(fim); dia = dia.AddDate(0, 0, 1) {
+			if event.ExDates[formatDate(dia)] {
+				continue
+			}
+
+			holidays = append(holidays, Holiday{
+				Date: formatDate(dia),
+				Name: event.Summary,
+				Type: "importado",
+			})
+		}
+	}
+
+	return holidays, errors, nil
+}
+
+func parseImportedHolidayDate(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "02/01/2006"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return formatDate(parsed), true
+		}
+	}
+	if len(value) > 10 {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			return formatDate(parsed), true
+		}
+	}
+	return "", false
+}
+
+func importedHolidayType(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "national", "nacional":
+		return "nacional"
+	case "state", "estadual":
+		return "estadual"
+	case "municipal":
+		return "municipal"
+	case "facultativo", "optional":
+		return "facultativo"
+	}
+	return "importado"
+}
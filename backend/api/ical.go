@@ -0,0 +1,554 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type ICSImportOptions struct {
+	StartDate     string `json:"startDate"`
+	EndDate       string `json:"endDate"`
+	DefaultTaskID int    `json:"defaultTaskId,omitempty"`
+}
+
+type icsProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+type icsEvent struct {
+	Index        int
+	UID          string
+	Summary      string
+	Organizer    string
+	Status       string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	RRule        string
+	ExDates      map[string]bool
+	RecurrenceID string
+	Err          error
+}
+
+var icsDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+func (t *TeamworkAPI) ExportTimeEntriesICS(startDate, endDate string) (string, error) {
+	if !t.IsConfigured() {
+		return "", fmt.Errorf("API não configurada")
+	}
+
+	entries, err := t.GetTimeEntriesForPeriodV2(startDate, endDate, false)
+	if err != nil {
+		return "", fmt.Errorf("erro ao obter entradas de tempo: %v", err)
+	}
+
+	reportsDir, err := getReportsDir()
+	if err != nil {
+		return "", err
+	}
+
+	filePath := filepath.Join(reportsDir, fmt.Sprintf("TeamworkExport_%s_%s.ics", startDate, endDate))
+	if err := writeFileAtomically(filePath, RenderTimeEntriesICS(entries, time.Now())); err != nil {
+		return "", err
+	}
+
+	t.logDebug("Exportação iCalendar salva em: %s", filePath)
+	return filePath, nil
+}
+
+func RenderTimeEntriesICS(entries []TimeEntryReport, generatedAt time.Time) []byte {
+	var buf bytes.Buffer
+	write := func(line string) {
+		buf.WriteString(foldICSLine(line))
+	}
+
+	dtstamp := generatedAt.UTC().Format("20060102T150405Z")
+
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:-//Naipe Logger//Lançamentos Teamwork//PT")
+	write("CALSCALE:GREGORIAN")
+	write("METHOD:PUBLISH")
+	write("X-WR-CALNAME:" + escapeICSText("Lançamentos Teamwork"))
+
+	for _, entry := range entries {
+		date, err := time.ParseInLocation("2006-01-02", entry.Date, time.Local)
+		if err != nil {
+			continue
+		}
+
+		summary := entry.TaskName
+		if entry.ProjectName != "" {
+			summary = fmt.Sprintf("%s - %s", entry.TaskName, entry.ProjectName)
+		}
+
+		details := make([]string, 0, 4)
+		if entry.Description != "" {
+			details = append(details, entry.Description)
+		}
+		details = append(details, fmt.Sprintf("Duração: %s", formatMinutesAsHours(entry.Minutes)))
+		details = append(details, fmt.Sprintf("Faturável: %s", exportText(entry.IsBillable, false)))
+		if entry.TaskID > 0 {
+			details = append(details, fmt.Sprintf("Tarefa: #%d", entry.TaskID))
+		}
+
+		write("BEGIN:VEVENT")
+		write(fmt.Sprintf("UID:timelog-%d@naipe-logger", entry.ID))
+		write("DTSTAMP:" + dtstamp)
+
+		if start, err := parseClock(entry.StartTime); err == nil && entry.StartTime != "" {
+			inicio := date.Add(time.Duration(start) * time.Minute)
+			fim := inicio.Add(time.Duration(entry.Minutes) * time.Minute)
+			write("DTSTART:" + inicio.Format("20060102T150405"))
+			write("DTEND:" + fim.Format("20060102T150405"))
+		} else {
+			write("DTSTART;VALUE=DATE:" + date.Format("20060102"))
+			write("DTEND;VALUE=DATE:" + date.AddDate(0, 0, 1).Format("20060102"))
+		}
+
+		write("SUMMARY:" + escapeICSText(summary))
+		write("DESCRIPTION:" + escapeICSText(strings.Join(details, "\n")))
+		if len(entry.Tags) > 0 {
+			names := tagNames(entry.Tags)
+			for i := range names {
+				names[i] = escapeICSText(names[i])
+			}
+			write("CATEGORIES:" + strings.Join(names, ","))
+		}
+		write("TRANSP:TRANSPARENT")
+		write("END:VEVENT")
+	}
+
+	write("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\r\n", "\\n", "\n", "\\n", "\r", "\\n")
+	return replacer.Replace(value)
+}
+
+func unescapeICSText(value string) string {
+	replacer := strings.NewReplacer("\\\\", "\\", "\\;", ";", "\\,", ",", "\\n", "\n", "\\N", "\n")
+	return replacer.Replace(value)
+}
+
+func foldICSLine(line string) string {
+	var sb strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	sb.WriteString(line)
+	sb.WriteString("\r\n")
+	return sb.String()
+}
+
+func (t *TeamworkAPI) ImportICS(content string, options ICSImportOptions) (*ImportResult, error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("arquivo iCalendar vazio")
+	}
+
+	inicio, err := time.ParseInLocation("2006-01-02", options.StartDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("data inicial inválida: %v", err)
+	}
+
+	fim, err := time.ParseInLocation("2006-01-02", options.EndDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("data final inválida: %v", err)
+	}
+
+	if fim.Before(inicio) {
+		return nil, fmt.Errorf("a data final deve ser igual ou posterior à data inicial")
+	}
+
+	rules, err := compileMappingRules(t.currentMappingRules())
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := parseICSEvents(content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		WorkDays: []WorkDay{},
+		Errors:   []ImportRowError{},
+	}
+
+	overrides := make(map[string]bool)
+	for _, event := range events {
+		if event.RecurrenceID != "" {
+			overrides[event.UID+"|"+event.RecurrenceID] = true
+		}
+	}
+
+	workDays := make(map[string]*WorkDay)
+	for _, event := range events {
+		if event.Err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Row: event.Index, Value: event.Summary, Message: event.Err.Error()})
+			continue
+		}
+
+		if strings.EqualFold(event.Status, "CANCELLED") {
+			continue
+		}
+
+		occurrences, err := event.occurrencesBetween(inicio, fim, overrides)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Row: event.Index, Column: "RRULE", Value: event.RRule, Message: err.Error()})
+			continue
+		}
+
+		if len(occurrences) == 0 {
+			continue
+		}
+
+		if event.AllDay {
+			result.TotalRows += len(occurrences)
+			result.Errors = append(result.Errors, ImportRowError{Row: event.Index, Value: event.Summary, Message: "evento de dia inteiro ignorado"})
+			continue
+		}
+
+		minutes := int(event.End.Sub(event.Start).Minutes())
+		description := event.Summary
+		match := matchCompiledRules(rules, MappingInput{CalendarTitle: event.Summary, Organizer: event.Organizer})
+		taskID, billable, matched := match.TaskID, match.IsBillable, match.Matched
+		if matched && match.Description != "" {
+			description = match.Description
+		}
+		if !matched && options.DefaultTaskID > 0 {
+			taskID, billable, matched = options.DefaultTaskID, true, true
+		}
+
+		result.TotalRows += len(occurrences)
+
+		if minutes <= 0 {
+			result.Errors = append(result.Errors, ImportRowError{Row: event.Index, Column: "DTEND", Value: event.Summary, Message: "o evento não possui duração"})
+			continue
+		}
+
+		if !matched {
+			result.Errors = append(result.Errors, ImportRowError{Row: event.Index, Column: "SUMMARY", Value: event.Summary, Message: "nenhuma regra corresponde ao evento"})
+			continue
+		}
+
+		for _, occurrence := range occurrences {
+			dateStr := formatDate(occurrence)
+			workDay, ok := workDays[dateStr]
+			if !ok {
+				workDay = &WorkDay{Date: dateStr, Entries: []EntryTask{}}
+				workDays[dateStr] = workDay
+			}
+
+			planEntry := t.importPlanEntry(taskID, TimeEntry{
+				Minutes:     minutes,
+				Time:        event.Start.Format("15:04"),
+				Description: description,
+				IsBillable:  billable,
+				Date:        dateStr,
+			})
+			workDay.Entries = append(workDay.Entries, planEntry)
+			workDay.TotalMin += planEntry.Entry.Minutes
+			result.ImportedRows++
+			result.TotalMinutes += planEntry.Entry.Minutes
+		}
+	}
+
+	for _, workDay := range workDays {
+		sort.SliceStable(workDay.Entries, func(i, j int) bool {
+			return workDay.Entries[i].Entry.Time < workDay.Entries[j].Entry.Time
+		})
+		result.WorkDays = append(result.WorkDays, *workDay)
+	}
+
+	sort.Slice(result.WorkDays, func(i, j int) bool {
+		return result.WorkDays[i].Date < result.WorkDays[j].Date
+	})
+
+	return result, nil
+}
+
+func (e icsEvent) occurrencesBetween(inicio, fim time.Time, overrides map[string]bool) ([]time.Time, error) {
+	startDay := time.Date(e.Start.Year(), e.Start.Month(), e.Start.Day(), 0, 0, 0, 0, time.Local)
+
+	if e.RRule == "" {
+		if startDay.Before(inicio) || startDay.After(fim) {
+			return nil, nil
+		}
+		return []time.Time{startDay}, nil
+	}
+
+	rule, err := ParseRecurrenceRule(e.RRule)
+	if err != nil {
+		return nil, err
+	}
+	rule.Start = dateOnly(startDay)
+
+	dates := make([]string, 0)
+	for date := range rule.Occurrences(inicio, fim) {
+		if e.ExDates[date] || overrides[e.UID+"|"+date] {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	occurrences := make([]time.Time, 0, len(dates))
+	for _, date := range dates {
+		day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err == nil {
+			occurrences = append(occurrences, day)
+		}
+	}
+	return occurrences, nil
+}
+
+func parseICSEvents(content string) ([]icsEvent, error) {
+	lines := unfoldICSLines(content)
+
+	var events []icsEvent
+	var current *icsEvent
+	var duration string
+	depth := 0
+
+	for _, line := range lines {
+		prop, ok := parseICSProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			if strings.EqualFold(prop.Value, "VEVENT") && current == nil {
+				current = &icsEvent{Index: len(events) + 1, ExDates: make(map[string]bool)}
+				duration = ""
+				depth = 0
+				continue
+			}
+			if current != nil {
+				depth++
+			}
+			continue
+		case "END":
+			if current == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if strings.EqualFold(prop.Value, "VEVENT") {
+				current.finish(duration)
+				events = append(events, *current)
+				current = nil
+			}
+			continue
+		}
+
+		if current == nil || depth > 0 {
+			continue
+		}
+
+		var err error
+		switch prop.Name {
+		case "UID":
+			current.UID = prop.Value
+		case "SUMMARY":
+			current.Summary = strings.TrimSpace(unescapeICSText(prop.Value))
+		case "STATUS":
+			current.Status = strings.TrimSpace(prop.Value)
+		case "ORGANIZER":
+			organizer := strings.TrimPrefix(strings.TrimPrefix(prop.Value, "mailto:"), "MAILTO:")
+			if cn := strings.Trim(prop.Params["CN"], `"`); cn != "" {
+				organizer = cn + " " + organizer
+			}
+			current.Organizer = strings.TrimSpace(organizer)
+		case "DTSTART":
+			current.Start, current.AllDay, err = parseICSDateTime(prop)
+		case "DTEND":
+			current.End, _, err = parseICSDateTime(prop)
+		case "DURATION":
+			duration = prop.Value
+		case "RRULE":
+			current.RRule = prop.Value
+		case "EXDATE":
+			for _, value := range strings.Split(prop.Value, ",") {
+				exdate, _, exErr := parseICSDateTime(icsProperty{Name: prop.Name, Params: prop.Params, Value: value})
+				if exErr == nil {
+					current.ExDates[formatDate(exdate)] = true
+				}
+			}
+		case "RECURRENCE-ID":
+			var recurrenceID time.Time
+			recurrenceID, _, err = parseICSDateTime(prop)
+			if err == nil {
+				current.RecurrenceID = formatDate(recurrenceID)
+			}
+		}
+
+		if err != nil && current.Err == nil {
+			current.Err = fmt.Errorf("%s inválido: %v", prop.Name, err)
+		}
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("nenhum evento encontrado no arquivo iCalendar")
+	}
+
+	return events, nil
+}
+
+func (e *icsEvent) finish(duration string) {
+	if e.Err != nil {
+		return
+	}
+
+	if e.Start.IsZero() {
+		e.Err = fmt.Errorf("evento sem DTSTART")
+		return
+	}
+
+	if e.End.IsZero() {
+		switch {
+		case duration != "":
+			d, err := parseICSDuration(duration)
+			if err != nil {
+				e.Err = err
+				return
+			}
+			e.End = e.Start.Add(d)
+		case e.AllDay:
+			e.End = e.Start.AddDate(0, 0, 1)
+		default:
+			e.End = e.Start
+		}
+	}
+
+	if e.RRule != "" {
+		parts := make([]string, 0)
+		for _, part := range strings.Split(e.RRule, ";") {
+			if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(part)), "WKST=") {
+				parts = append(parts, part)
+			}
+		}
+		e.RRule = strings.Join(parts, ";")
+	}
+}
+
+func unfoldICSLines(content string) []string {
+	raw := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	lines := make([]string, 0, len(raw))
+	for _, line := range raw {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+	return lines
+}
+
+func parseICSProperty(line string) (icsProperty, bool) {
+	colon := -1
+	inQuotes := false
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return icsProperty{}, false
+	}
+
+	segments := strings.Split(line[:colon], ";")
+	prop := icsProperty{
+		Name:   strings.ToUpper(strings.TrimSpace(segments[0])),
+		Params: make(map[string]string),
+		Value:  line[colon+1:],
+	}
+
+	for _, segment := range segments[1:] {
+		keyValue := strings.SplitN(segment, "=", 2)
+		if len(keyValue) == 2 {
+			prop.Params[strings.ToUpper(keyValue[0])] = keyValue[1]
+		}
+	}
+
+	return prop, true
+}
+
+func parseICSDateTime(prop icsProperty) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.Value)
+
+	if strings.EqualFold(prop.Params["VALUE"], "DATE") || len(value) == 8 {
+		date, err := time.ParseInLocation("20060102", value, time.Local)
+		return date, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		date, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		return date.In(time.Local), false, nil
+	}
+
+	location := time.Local
+	if tzid := strings.Trim(prop.Params["TZID"], `"`); tzid != "" {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+
+	date, err := time.ParseInLocation("20060102T150405", value, location)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return date.In(time.Local), false, nil
+}
+
+func parseICSDuration(value string) (time.Duration, error) {
+	match := icsDurationPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, fmt.Errorf("DURATION inválido: %s", value)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(match[i+2])
+		total += time.Duration(n) * unit
+	}
+
+	if match[1] == "-" {
+		total = -total
+	}
+	return total, nil
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type MappingRule struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	Source              string `json:"source"`
+	MatchType           string `json:"matchType"`
+	Pattern             string `json:"pattern"`
+	TaskID              int    `json:"taskId"`
+	IsBillable          bool   `json:"isBillable"`
+	DescriptionTemplate string `json:"descriptionTemplate,omitempty"`
+	Disabled            bool   `json:"disabled,omitempty"`
+}
+
+type MappingInput struct {
+	Description   string `json:"description,omitempty"`
+	CalendarTitle string `json:"calendarTitle,omitempty"`
+	Organizer     string `json:"organizer,omitempty"`
+	Repository    string `json:"repository,omitempty"`
+	Branch        string `json:"branch,omitempty"`
+}
+
+type MappingMatch struct {
+	Matched     bool   `json:"matched"`
+	RuleID      string `json:"ruleId,omitempty"`
+	RuleName    string `json:"ruleName,omitempty"`
+	RuleIndex   int    `json:"ruleIndex"`
+	Source      string `json:"source,omitempty"`
+	MatchedText string `json:"matchedText,omitempty"`
+	TaskID      int    `json:"taskId,omitempty"`
+	IsBillable  bool   `json:"isBillable"`
+	Description string `json:"description,omitempty"`
+}
+
+type compiledMappingRule struct {
+	rule    MappingRule
+	index   int
+	regex   *regexp.Regexp
+	keyword string
+}
+
+var mappingRuleSources = map[string]bool{
+	"description": true,
+	"calendar":    true,
+	"organizer":   true,
+	"repository":  true,
+	"branch":      true,
+	"any":         true,
+}
+
+var templatePlaceholder = regexp.MustCompile(`\{([a-zA-Z]+|\d+)\}`)
+
+func ValidateMappingRules(rules []MappingRule) error {
+	_, err := compileMappingRules(rules)
+	return err
+}
+
+func compileMappingRules(rules []MappingRule) ([]compiledMappingRule, error) {
+	compiled := make([]compiledMappingRule, 0, len(rules))
+	for i, rule := range rules {
+		nome := rule.Name
+		if nome == "" {
+			nome = fmt.Sprintf("#%d", i+1)
+		}
+
+		source := strings.ToLower(strings.TrimSpace(rule.Source))
+		if source == "" {
+			source = "description"
+		}
+		if !mappingRuleSources[source] {
+			return nil, fmt.Errorf("regra %s: origem inválida %q", nome, rule.Source)
+		}
+		rule.Source = source
+
+		if strings.TrimSpace(rule.Pattern) == "" {
+			return nil, fmt.Errorf("regra %s: padrão não informado", nome)
+		}
+		if rule.TaskID <= 0 {
+			return nil, fmt.Errorf("regra %s: ID de tarefa inválido", nome)
+		}
+
+		entry := compiledMappingRule{rule: rule, index: i}
+		switch strings.ToLower(strings.TrimSpace(rule.MatchType)) {
+		case "", "keyword":
+			entry.keyword = normalizeText(rule.Pattern)
+		case "regex":
+			regex, err := regexp.Compile("(?i)" + rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("regra %s: expressão regular inválida: %v", nome, err)
+			}
+			entry.regex = regex
+		default:
+			return nil, fmt.Errorf("regra %s: tipo de correspondência inválido %q (use regex ou keyword)", nome, rule.MatchType)
+		}
+
+		if rule.Disabled {
+			continue
+		}
+		compiled = append(compiled, entry)
+	}
+	return compiled, nil
+}
+
+func (t *TeamworkAPI) SetMappingRules(rules []MappingRule) {
+	t.settingsMutex.Lock()
+	defer t.settingsMutex.Unlock()
+	t.mappingRules = rules
+}
+
+func (t *TeamworkAPI) currentMappingRules() []MappingRule {
+	t.settingsMutex.RLock()
+	defer t.settingsMutex.RUnlock()
+	return t.mappingRules
+}
+
+func (t *TeamworkAPI) MatchMappingRules(input MappingInput) (MappingMatch, error) {
+	return MatchMappingRules(t.currentMappingRules(), input)
+}
+
+func MatchMappingRules(rules []MappingRule, input MappingInput) (MappingMatch, error) {
+	compiled, err := compileMappingRules(rules)
+	if err != nil {
+		return MappingMatch{RuleIndex: -1}, err
+	}
+	return matchCompiledRules(compiled, input), nil
+}
+
+func matchCompiledRules(rules []compiledMappingRule, input MappingInput) MappingMatch {
+	for _, rule := range rules {
+		for _, source := range rule.sources() {
+			text := input.field(source)
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+
+			groups := rule.match(text)
+			if groups == nil {
+				continue
+			}
+
+			return MappingMatch{
+				Matched:     true,
+				RuleID:      rule.rule.ID,
+				RuleName:    rule.rule.Name,
+				RuleIndex:   rule.index,
+				Source:      source,
+				MatchedText: groups[0],
+				TaskID:      rule.rule.TaskID,
+				IsBillable:  rule.rule.IsBillable,
+				Description: renderMappingTemplate(rule.rule.DescriptionTemplate, text, groups, input),
+			}
+		}
+	}
+	return MappingMatch{RuleIndex: -1}
+}
+
+func (r compiledMappingRule) sources() []string {
+	if r.rule.Source == "any" {
+		return []string{"description", "calendar", "organizer", "repository", "branch"}
+	}
+	return []string{r.rule.Source}
+}
+
+func (r compiledMappingRule) match(text string) []string {
+	if r.regex != nil {
+		return r.regex.FindStringSubmatch(text)
+	}
+
+	if strings.Contains(normalizeText(text), r.keyword) {
+		return []string{r.rule.Pattern}
+	}
+	return nil
+}
+
+func (i MappingInput) field(source string) string {
+	switch source {
+	case "description":
+		return i.Description
+	case "calendar":
+		return i.CalendarTitle
+	case "organizer":
+		return i.Organizer
+	case "repository":
+		return i.Repository
+	case "branch":
+		return i.Branch
+	}
+	return ""
+}
+
+func renderMappingTemplate(template, text string, groups []string, input MappingInput) string {
+	if strings.TrimSpace(template) == "" {
+		return ""
+	}
+
+	return templatePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if index, err := strconv.Atoi(name); err == nil {
+			if index < len(groups) {
+				return groups[index]
+			}
+			return ""
+		}
+
+		switch strings.ToLower(name) {
+		case "text":
+			return text
+		case "match":
+			return groups[0]
+		case "description":
+			return input.Description
+		case "title":
+			return input.CalendarTitle
+		case "organizer":
+			return input.Organizer
+		case "repo", "repository":
+			return input.Repository
+		case "branch":
+			return input.Branch
+		}
+		return placeholder
+	})
+}
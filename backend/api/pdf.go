@@ -0,0 +1,199 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+)
+
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+type pdfDocument struct {
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+}
+
+func newPDFDocument() *pdfDocument {
+	return &pdfDocument{}
+}
+
+func (d *pdfDocument) addPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+}
+
+func (d *pdfDocument) pageCount() int {
+	return len(d.pages)
+}
+
+func (d *pdfDocument) setPage(index int) {
+	if index >= 0 && index < len(d.pages) {
+		d.current = d.pages[index]
+	}
+}
+
+func (d *pdfDocument) text(x, y, size float64, bold bool, value string) {
+	if d.current == nil {
+		d.addPage()
+	}
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(d.current, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+		font, size, x, pdfPageHeight-y, pdfEscape(encodeWinAnsi(value)))
+}
+
+func (d *pdfDocument) textRight(x, y, size float64, bold bool, value string) {
+	d.text(x-pdfTextWidth(value, size, bold), y, size, bold, value)
+}
+
+func (d *pdfDocument) line(x1, y1, x2, y2, width float64) {
+	if d.current == nil {
+		d.addPage()
+	}
+
+	fmt.Fprintf(d.current, "%.2f w %.2f %.2f m %.2f %.2f l S\n",
+		width, x1, pdfPageHeight-y1, x2, pdfPageHeight-y2)
+}
+
+func (d *pdfDocument) fillRect(x, y, width, height, gray float64) {
+	if d.current == nil {
+		d.addPage()
+	}
+
+	fmt.Fprintf(d.current, "q %.2f g %.2f %.2f %.2f %.2f re f Q\n",
+		gray, x, pdfPageHeight-y-height, width, height)
+}
+
+func (d *pdfDocument) render() []byte {
+	if len(d.pages) == 0 {
+		d.addPage()
+	}
+
+	var buf bytes.Buffer
+	offsets := make([]int, 0)
+
+	writeObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	firstPageObj := 5
+	kids := make([]string, 0, len(d.pages))
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPageObj+i*2))
+	}
+
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, firstPageObj+i*2+1))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	return buf.Bytes()
+}
+
+func encodeWinAnsi(value string) []byte {
+	encoded := make([]byte, 0, len(value))
+	for _, r := range value {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			encoded = append(encoded, ' ')
+		case r >= 32 && r < 127:
+			encoded = append(encoded, byte(r))
+		case r >= 0xA0 && r <= 0xFF:
+			encoded = append(encoded, byte(r))
+		default:
+			if b, ok := winAnsiExtras[r]; ok {
+				encoded = append(encoded, b)
+			} else {
+				encoded = append(encoded, '?')
+			}
+		}
+	}
+	return encoded
+}
+
+func pdfEscape(value []byte) string {
+	var sb strings.Builder
+	for _, b := range value {
+		switch b {
+		case '\\', '(', ')':
+			sb.WriteByte('\\')
+			sb.WriteByte(b)
+		default:
+			sb.WriteByte(b)
+		}
+	}
+	return sb.String()
+}
+
+func pdfTextWidth(value string, size float64, bold bool) float64 {
+	total := 0
+	for _, b := range encodeWinAnsi(value) {
+		if b >= 32 && b < 127 {
+			total += helveticaWidths[b-32]
+		} else {
+			total += 556
+		}
+	}
+
+	width := float64(total) * size / 1000
+	if bold {
+		width *= 1.06
+	}
+	return width
+}
+
+func pdfFitText(value string, size float64, bold bool, maxWidth float64) string {
+	if pdfTextWidth(value, size, bold) <= maxWidth {
+		return value
+	}
+
+	runes := []rune(value)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "..."
+		if pdfTextWidth(candidate, size, bold) <= maxWidth {
+			return candidate
+		}
+	}
+	return ""
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type LockedPeriod struct {
+	ID        string            `json:"id"`
+	StartDate string            `json:"startDate"`
+	EndDate   string            `json:"endDate"`
+	Reason    string            `json:"reason,omitempty"`
+	Locked    bool              `json:"locked"`
+	History   []PeriodLockEvent `json:"history"`
+}
+
+type PeriodLockEvent struct {
+	Action string `json:"action"`
+	By     string `json:"by"`
+	At     string `json:"at"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func ValidateLockedPeriod(period LockedPeriod) error {
+	inicio, err := time.Parse("2006-01-02", period.StartDate)
+	if err != nil {
+		return fmt.Errorf("data inicial inválida: %v", err)
+	}
+
+	fim, err := time.Parse("2006-01-02", period.EndDate)
+	if err != nil {
+		return fmt.Errorf("data final inválida: %v", err)
+	}
+
+	if fim.Before(inicio) {
+		return fmt.Errorf("a data final deve ser igual ou posterior à data inicial")
+	}
+
+	return nil
+}
+
+func (p LockedPeriod) Contains(date string) bool {
+	return p.Locked && date >= p.StartDate && date <= p.EndDate
+}
+
+func (t *TeamworkAPI) SetLockedPeriods(periods []LockedPeriod) {
+	t.settingsMutex.Lock()
+	defer t.settingsMutex.Unlock()
+	t.lockedPeriods = periods
+}
+
+func (t *TeamworkAPI) currentLockedPeriods() []LockedPeriod {
+	t.settingsMutex.RLock()
+	defer t.settingsMutex.RUnlock()
+	return t.lockedPeriods
+}
+
+func (t *TeamworkAPI) FindLockedPeriod(date string) (*LockedPeriod, bool) {
+	if len(date) > 10 {
+		date = date[:10]
+	}
+
+	for _, period := range t.currentLockedPeriods() {
+		if period.Contains(date) {
+			return &period, true
+		}
+	}
+	return nil, false
+}
+
+func (t *TeamworkAPI) ensureDateUnlocked(date string) error {
+	period, locked := t.FindLockedPeriod(date)
+	if !locked {
+		return nil
+	}
+
+	message := fmt.Sprintf("a data %s está em um período bloqueado (%s a %s)",
+		formatDisplayDate(date[:minValue(len(date), 10)]), formatDisplayDate(period.StartDate), formatDisplayDate(period.EndDate))
+	if period.Reason != "" {
+		message += ": " + period.Reason
+	}
+	return fmt.Errorf("%s", message)
+}
+
+func (t *TeamworkAPI) ensureEntryUnlocked(entryID int) error {
+	if len(t.currentLockedPeriods()) == 0 {
+		return nil
+	}
+
+	info, err := t.fetchTimeEntryInfo(entryID)
+	if err != nil {
+		return fmt.Errorf("não foi possível verificar o bloqueio de período da entrada %d: %v", entryID, err)
+	}
+
+	return t.ensureDateUnlocked(info.Date)
+}
+
+type timeEntryInfo struct {
+	Date      string
+	TaskID    int
+	ProjectID int
+}
+
+func (t *TeamworkAPI) fetchTimeEntryInfo(entryID int) (timeEntryInfo, error) {
+	url := t.buildURL(fmt.Sprintf("/projects/api/v3/time/%d.json", entryID))
+
+	req, err := t.createRequest("GET", url, nil)
+	if err != nil {
+		return timeEntryInfo{}, err
+	}
+
+	resp, body, err := t.doRequest(req)
+	if err != nil {
+		return timeEntryInfo{}, err
+	}
+
+	if resp.StatusCode != 200 {
+		return timeEntryInfo{}, fmt.Errorf("erro ao obter entrada de tempo: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return timeEntryInfo{}, fmt.Errorf("erro ao decodificar resposta: %v", err)
+	}
+
+	for _, key := range []string{"timelog", "timeEntry", "time-entry"} {
+		raw, ok := response[key]
+		if !ok {
+			continue
+		}
+
+		var entry struct {
+			Date       string `json:"date"`
+			TimeLogged string `json:"timeLogged"`
+			TaskID     int    `json:"taskId"`
+			ProjectID  int    `json:"projectId"`
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+
+		info := timeEntryInfo{TaskID: entry.TaskID, ProjectID: entry.ProjectID}
+		for _, value := range []string{entry.TimeLogged, entry.Date} {
+			value = strings.TrimSpace(value)
+			if len(value) >= 10 {
+				if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+					info.Date = formatDate(parsed.In(time.Local))
+				} else {
+					info.Date = value[:10]
+				}
+				return info, nil
+			}
+		}
+	}
+
+	return timeEntryInfo{}, fmt.Errorf("data da entrada não encontrada na resposta")
+}
@@ -0,0 +1,468 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultDayStart = 9 * 60
+
+func (t *TeamworkAPI) CreateGapFillingPlan(month, year int, tasks []Task) ([]WorkDay, error) {
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("nenhuma tarefa selecionada para completar as horas")
+	}
+
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("mês inválido: %d", month)
+	}
+
+	firstDay := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	lastDay := firstDay.AddDate(0, 1, -1)
+	startDate := formatDate(firstDay)
+	endDate := formatDate(lastDay)
+
+	diasUteis, err := t.GetWorkingDays(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := t.GetTimeEntriesForPeriodV2(startDate, endDate, false)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter entradas de tempo do mês: %v", err)
+	}
+
+	loggedByDay := make(map[string]int)
+	lastEndByDay := make(map[string]int)
+	for _, entry := range entries {
+		loggedByDay[entry.Date] += entry.Minutes
+
+		if entry.StartTime == "" {
+			continue
+		}
+
+		start, err := parseClock(entry.StartTime)
+		if err != nil {
+			continue
+		}
+
+		if end := start + entry.Minutes; end > lastEndByDay[entry.Date] {
+			lastEndByDay[entry.Date] = end
+		}
+	}
+
+	tasks = t.schedulableTasks(tasks)
+	plano := make([]WorkDay, 0, len(diasUteis))
+
+	for _, dia := range diasUteis {
+		diaData, err := time.Parse("2006-01-02", dia)
+		if err != nil {
+			continue
+		}
+
+		faltante := t.expectedMinutes(diaData) - loggedByDay[dia]
+		if faltante <= 0 {
+			t.logDebug("Dia %s já possui %d minutos lançados, nada a completar", dia, loggedByDay[dia])
+			continue
+		}
+
+		tarefasDoDia := make([]Task, 0, len(tasks))
+		for _, tarefa := range tasks {
+			if taskAppliesOn(tarefa, diaData) {
+				tarefasDoDia = append(tarefasDoDia, tarefa)
+			}
+		}
+
+		if len(tarefasDoDia) == 0 {
+			continue
+		}
+
+		inicioDia := t.planDayStart(dia)
+		inicio := workDayStart(inicioDia, lastEndByDay[dia])
+		excedente := 0
+
+		workDay := WorkDay{
+			Date:    dia,
+			Entries: []EntryTask{},
+		}
+
+		alocacao, minimosReduzidos := distributeWeighted(faltante, tarefasDoDia, 1)
+		if minimosReduzidos {
+			workDay.Warnings = append(workDay.Warnings, minimumsWarning(faltante))
+		}
+		for i, tarefa := range tarefasDoDia {
+			if alocacao[i] <= 0 {
+				continue
+			}
+
+			minutos := alocacao[i]
+			if !t.Config.Schedule.Enabled {
+				disponivel := fimDoDia - inicio
+				if disponivel < 0 {
+					disponivel = 0
+				}
+				if minutos > disponivel {
+					excedente += minutos - disponivel
+					minutos = disponivel
+				}
+				if minutos == 0 {
+					continue
+				}
+			}
+
+			entrada := entryTemplateFor(tarefa)
+			entrada.Minutes = minutos
+			entrada.Time = formatClock(inicio)
+			entrada.Date = dia
+
+			planEntry := t.newPlanEntry(tarefa, entrada)
+			workDay.Entries = append(workDay.Entries, planEntry)
+			workDay.TotalMin += planEntry.Entry.Minutes
+			inicio += planEntry.Entry.Minutes
+		}
+
+		if excedente > 0 {
+			workDay.Warnings = append(workDay.Warnings, dayOverflowWarning(excedente))
+		}
+		if t.Config.Schedule.Enabled {
+			t.layoutWorkDay(&workDay, workDayStart(inicioDia, lastEndByDay[dia]))
+		}
+
+		t.logDebug("Dia %s: %d minutos lançados, completando %d minutos", dia, loggedByDay[dia], faltante)
+		plano = append(plano, workDay)
+	}
+
+	return plano, nil
+}
+
+func (t *TeamworkAPI) CreateWeightedDistributionPlan(diasUteis []string, tarefas []Task, options WeightedDistributionOptions) []WorkDay {
+	tarefas = t.schedulableTasks(tarefas)
+	plano := make([]WorkDay, 0, len(diasUteis))
+
+	for _, dia := range diasUteis {
+		diaData, err := time.Parse("2006-01-02", dia)
+		if err != nil {
+			t.logDebug("Erro ao fazer parse da data %s: %v", dia, err)
+			continue
+		}
+
+		capacidadeDia := t.dayCapacity(diaData)
+		if options.CapacityMinutes > 0 {
+			capacidadeDia = scaleMinutes(options.CapacityMinutes, t.dayFraction(diaData))
+		}
+		if capacidadeDia <= 0 {
+			t.logDebug("Dia %s marcado como ausência, pulando", dia)
+			continue
+		}
+
+		tarefasDoDia := make([]Task, 0, len(tarefas))
+		for _, tarefa := range tarefas {
+			if taskAppliesOn(tarefa, diaData) {
+				tarefasDoDia = append(tarefasDoDia, tarefa)
+			}
+		}
+
+		alocacao, minimosReduzidos := distributeWeighted(capacidadeDia, tarefasDoDia, options.Granularity)
+
+		workDay := WorkDay{
+			Date:    dia,
+			Entries: []EntryTask{},
+		}
+		if minimosReduzidos {
+			workDay.Warnings = append(workDay.Warnings, minimumsWarning(capacidadeDia))
+		}
+
+		for i, tarefa := range tarefasDoDia {
+			if alocacao[i] <= 0 {
+				continue
+			}
+
+			entrada := entryTemplateFor(tarefa)
+			entrada.Minutes = alocacao[i]
+			entrada.Date = dia
+			if entrada.Time == "" {
+				entrada.Time = formatClock(t.planDayStart(dia))
+			}
+
+			planEntry := t.newPlanEntry(tarefa, entrada)
+			workDay.Entries = append(workDay.Entries, planEntry)
+			workDay.TotalMin += planEntry.Entry.Minutes
+		}
+
+		if len(workDay.Entries) > 0 {
+			if t.Config.Schedule.Enabled {
+				t.layoutWorkDay(&workDay, t.planDayStart(dia))
+			}
+			plano = append(plano, workDay)
+		}
+	}
+
+	t.logDebug("Plano ponderado gerado com %d dias", len(plano))
+	return plano
+}
+
+func ValidateTask(tarefa Task) error {
+	if err := ValidateTaskSchedule(tarefa); err != nil {
+		return err
+	}
+
+	if err := ValidateTaskRounding(tarefa); err != nil {
+		return err
+	}
+
+	if tarefa.MinPerDay < 0 || tarefa.MaxPerDay < 0 {
+		return fmt.Errorf("tarefa %d: limites diários não podem ser negativos", tarefa.TaskID)
+	}
+
+	if tarefa.MaxPerDay > 0 && tarefa.MinPerDay > tarefa.MaxPerDay {
+		return fmt.Errorf("tarefa %d: o mínimo diário (%d) não pode ser maior que o máximo (%d)", tarefa.TaskID, tarefa.MinPerDay, tarefa.MaxPerDay)
+	}
+
+	if tarefa.Weight < 0 {
+		return fmt.Errorf("tarefa %d: o peso não pode ser negativo", tarefa.TaskID)
+	}
+
+	return nil
+}
+
+func minimumsWarning(capacidade int) string {
+	return fmt.Sprintf("A soma dos mínimos diários das tarefas excede a capacidade do dia (%s); os mínimos foram reduzidos proporcionalmente",
+		formatMinutesAsHours(capacidade))
+}
+
+func distributeWeighted(capacidade int, tarefas []Task, granularidade int) ([]int, bool) {
+	n := len(tarefas)
+	alocacao := make([]int, n)
+	if n == 0 || capacidade <= 0 {
+		return alocacao, false
+	}
+
+	minimos := make([]int, n)
+	somaMinimos := 0
+	for i, tarefa := range tarefas {
+		minimos[i] = tarefa.MinPerDay
+		somaMinimos += tarefa.MinPerDay
+	}
+
+	minimosReduzidos := somaMinimos > capacidade
+	if minimosReduzidos {
+		for i := range minimos {
+			minimos[i] = minimos[i] * capacidade / somaMinimos
+		}
+	}
+
+	if granularidade <= 0 {
+		granularidade = 1
+	}
+
+	pesos := make([]float64, n)
+	pesoTotal := 0.0
+	for i, tarefa := range tarefas {
+		if tarefa.Weight > 0 {
+			pesos[i] = tarefa.Weight
+			pesoTotal += tarefa.Weight
+		}
+	}
+	if pesoTotal == 0 {
+		for i := range pesos {
+			pesos[i] = 1
+		}
+	}
+
+	partes := make([]float64, n)
+	fixas := make([]bool, n)
+	for {
+		restante := float64(capacidade)
+		pesoLivre := 0.0
+		for i := range tarefas {
+			if fixas[i] {
+				restante -= partes[i]
+			} else {
+				pesoLivre += pesos[i]
+			}
+		}
+
+		for i := range tarefas {
+			if fixas[i] {
+				continue
+			}
+			partes[i] = 0
+			if pesoLivre > 0 && restante > 0 {
+				partes[i] = restante * pesos[i] / pesoLivre
+			}
+		}
+
+		alterou := false
+		for i, tarefa := range tarefas {
+			if fixas[i] {
+				continue
+			}
+			if partes[i] < float64(minimos[i]) {
+				partes[i] = float64(minimos[i])
+				fixas[i] = true
+				alterou = true
+			} else if tarefa.MaxPerDay > 0 && partes[i] > float64(tarefa.MaxPerDay) {
+				partes[i] = float64(tarefa.MaxPerDay)
+				fixas[i] = true
+				alterou = true
+			}
+		}
+
+		if !alterou {
+			break
+		}
+	}
+
+	fracoes := make([]float64, n)
+	soma := 0
+	for i := range tarefas {
+		unidades := int(partes[i] / float64(granularidade))
+		alocacao[i] = unidades * granularidade
+		fracoes[i] = partes[i]/float64(granularidade) - float64(unidades)
+		if alocacao[i] < minimos[i] {
+			alocacao[i] = minimos[i]
+		}
+		soma += alocacao[i]
+	}
+
+	temEspaco := func(i, minutos int) bool {
+		if pesos[i] == 0 {
+			return false
+		}
+		max := tarefas[i].MaxPerDay
+		return max <= 0 || alocacao[i]+minutos <= max
+	}
+
+	ordem := make([]int, n)
+	for i := range ordem {
+		ordem[i] = i
+	}
+	sort.SliceStable(ordem, func(a, b int) bool {
+		return fracoes[ordem[a]] > fracoes[ordem[b]]
+	})
+
+	unidadesRestantes := (capacidade - soma) / granularidade
+	for unidadesRestantes > 0 {
+		distribuiu := false
+		for _, i := range ordem {
+			if unidadesRestantes == 0 {
+				break
+			}
+			if temEspaco(i, granularidade) {
+				alocacao[i] += granularidade
+				soma += granularidade
+				unidadesRestantes--
+				distribuiu = true
+			}
+		}
+		if !distribuiu {
+			break
+		}
+	}
+
+	if resto := capacidade - soma; resto > 0 && resto < granularidade {
+		escolhida := -1
+		for i := range tarefas {
+			if temEspaco(i, resto) && (escolhida < 0 || pesos[i] > pesos[escolhida]) {
+				escolhida = i
+			}
+		}
+		if escolhida >= 0 {
+			alocacao[escolhida] += resto
+		}
+	}
+
+	return alocacao, minimosReduzidos
+}
+
+func workDayStart(inicioDia, ultimoFim int) int {
+	if ultimoFim > inicioDia {
+		return ultimoFim
+	}
+	return inicioDia
+}
+
+func entryTemplateFor(tarefa Task) TimeEntry {
+	entrada := TimeEntry{
+		Description: tarefa.TaskName,
+		IsBillable:  true,
+	}
+
+	if len(tarefa.Entries) > 0 {
+		modelo := tarefa.Entries[0]
+		if modelo.Description != "" {
+			entrada.Description = modelo.Description
+		}
+		entrada.IsBillable = modelo.IsBillable
+		entrada.Time = modelo.Time
+		entrada.TagIDs = modelo.TagIDs
+	}
+
+	return entrada
+}
+
+func taskAppliesOn(tarefa Task, dia time.Time) bool {
+	data := formatDate(dia)
+	if tarefa.ValidFrom != "" && data < tarefa.ValidFrom {
+		return false
+	}
+	if tarefa.ValidUntil != "" && data > tarefa.ValidUntil {
+		return false
+	}
+
+	if len(tarefa.WorkingDays) > 0 {
+		diaSemana := int(dia.Weekday())
+		encontrado := false
+		for _, workingDay := range tarefa.WorkingDays {
+			if workingDay == diaSemana {
+				encontrado = true
+				break
+			}
+		}
+		if !encontrado {
+			return false
+		}
+	}
+
+	if tarefa.Recurrence == "" {
+		return true
+	}
+
+	rule, err := taskRecurrence(tarefa)
+	if err != nil {
+		return false
+	}
+
+	return rule.OccursOn(dia)
+}
+
+func parseClock(value string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) < 2 {
+		return 0, fmt.Errorf("horário inválido: %s", value)
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, fmt.Errorf("horário inválido: %s", value)
+	}
+
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("horário inválido: %s", value)
+	}
+
+	return hours*60 + minutes, nil
+}
+
+func formatClock(minutes int) string {
+	if minutes < 0 {
+		minutes = 0
+	}
+	if minutes >= 24*60 {
+		minutes = 24*60 - 1
+	}
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,509 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RecurrenceRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []WeekdayRule
+	ByMonthDay []int
+	ByMonth    []int
+	BySetPos   []int
+	Count      int
+	Until      time.Time
+	Start      time.Time
+}
+
+type WeekdayRule struct {
+	Weekday time.Weekday
+	Ordinal int
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	rule := &RecurrenceRule{Interval: 1}
+	ruleText := ""
+
+	for _, line := range strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		upper := strings.ToUpper(line)
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(upper, "DTSTART"):
+			idx := strings.LastIndex(line, ":")
+			if idx < 0 {
+				return nil, fmt.Errorf("DTSTART inválido: %s", line)
+			}
+			start, err := parseRecurrenceDate(line[idx+1:])
+			if err != nil {
+				return nil, fmt.Errorf("DTSTART inválido: %v", err)
+			}
+			rule.Start = start
+		case strings.HasPrefix(upper, "RRULE:"):
+			ruleText = line[len("RRULE:"):]
+		default:
+			ruleText = line
+		}
+	}
+
+	if ruleText == "" {
+		return nil, fmt.Errorf("regra de recorrência vazia")
+	}
+
+	for _, part := range strings.Split(ruleText, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		keyValue := strings.SplitN(part, "=", 2)
+		if len(keyValue) != 2 {
+			return nil, fmt.Errorf("parte inválida na regra de recorrência: %s", part)
+		}
+
+		key := strings.ToUpper(strings.TrimSpace(keyValue[0]))
+		val := strings.ToUpper(strings.TrimSpace(keyValue[1]))
+
+		var err error
+		switch key {
+		case "FREQ":
+			if val != "DAILY" && val != "WEEKLY" && val != "MONTHLY" && val != "YEARLY" {
+				return nil, fmt.Errorf("frequência não suportada: %s", val)
+			}
+			rule.Freq = val
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err != nil || rule.Interval <= 0 {
+				return nil, fmt.Errorf("INTERVAL inválido: %s", val)
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err != nil || rule.Count <= 0 {
+				return nil, fmt.Errorf("COUNT inválido: %s", val)
+			}
+		case "UNTIL":
+			rule.Until, err = parseRecurrenceDate(val)
+			if err != nil {
+				return nil, fmt.Errorf("UNTIL inválido: %v", err)
+			}
+		case "BYDAY":
+			rule.ByDay, err = parseWeekdayRules(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseIntList(val, -31, 31)
+		case "BYMONTH":
+			rule.ByMonth, err = parseIntList(val, 1, 12)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseIntList(val, -366, 366)
+		case "WKST":
+			if val != "MO" {
+				return nil, fmt.Errorf("apenas WKST=MO é suportado")
+			}
+		default:
+			return nil, fmt.Errorf("parâmetro de recorrência não suportado: %s", key)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("FREQ é obrigatório na regra de recorrência")
+	}
+
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("COUNT e UNTIL não podem ser usados juntos")
+	}
+
+	return rule, nil
+}
+
+func parseRecurrenceDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102", "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("data inválida: %s", value)
+}
+
+func parseWeekdayRules(value string) ([]WeekdayRule, error) {
+	var rules []WeekdayRule
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) < 2 {
+			return nil, fmt.Errorf("BYDAY inválido: %s", item)
+		}
+
+		weekday, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("dia da semana inválido em BYDAY: %s", item)
+		}
+
+		ordinal := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+			if err != nil || n == 0 || n > 53 || n < -53 {
+				return nil, fmt.Errorf("ordinal inválido em BYDAY: %s", item)
+			}
+			ordinal = n
+		}
+
+		rules = append(rules, WeekdayRule{Weekday: weekday, Ordinal: ordinal})
+	}
+
+	return rules, nil
+}
+
+func parseIntList(value string, min, max int) ([]int, error) {
+	var values []int
+
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(item), "+"))
+		if err != nil || n == 0 || n < min || n > max {
+			return nil, fmt.Errorf("valor inválido na regra de recorrência: %s", item)
+		}
+		values = append(values, n)
+	}
+
+	return values, nil
+}
+
+func (r *RecurrenceRule) needsStart() bool {
+	if r.Interval > 1 || r.Count > 0 {
+		return true
+	}
+
+	switch r.Freq {
+	case "WEEKLY":
+		return len(r.ByDay) == 0
+	case "MONTHLY":
+		return len(r.ByDay) == 0 && len(r.ByMonthDay) == 0
+	case "YEARLY":
+		return len(r.ByMonth) == 0 || (len(r.ByDay) == 0 && len(r.ByMonthDay) == 0)
+	}
+
+	return false
+}
+
+func (r *RecurrenceRule) Occurrences(from, to time.Time) map[string]bool {
+	result := make(map[string]bool)
+
+	from = dateOnly(from)
+	to = dateOnly(to)
+	start := r.Start
+	if start.IsZero() {
+		start = from
+	}
+
+	count := 0
+	for periodo := r.periodStart(start); !periodo.After(to); periodo = r.nextPeriod(periodo) {
+		for _, candidato := range applySetPos(r.expand(periodo, start), r.BySetPos) {
+			if candidato.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && candidato.After(r.Until) {
+				return result
+			}
+
+			count++
+			if r.Count > 0 && count > r.Count {
+				return result
+			}
+
+			if !candidato.Before(from) && !candidato.After(to) {
+				result[formatDate(candidato)] = true
+			}
+		}
+	}
+
+	return result
+}
+
+func (r *RecurrenceRule) OccursOn(date time.Time) bool {
+	return r.Occurrences(date, date)[formatDate(date)]
+}
+
+func dateOnly(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (r *RecurrenceRule) periodStart(date time.Time) time.Time {
+	switch r.Freq {
+	case "WEEKLY":
+		offset := (int(date.Weekday()) + 6) % 7
+		return date.AddDate(0, 0, -offset)
+	case "MONTHLY":
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case "YEARLY":
+		return time.Date(date.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return date
+}
+
+func (r *RecurrenceRule) nextPeriod(periodo time.Time) time.Time {
+	switch r.Freq {
+	case "WEEKLY":
+		return periodo.AddDate(0, 0, 7*r.Interval)
+	case "MONTHLY":
+		return periodo.AddDate(0, r.Interval, 0)
+	case "YEARLY":
+		return periodo.AddDate(r.Interval, 0, 0)
+	}
+	return periodo.AddDate(0, 0, r.Interval)
+}
+
+func (r *RecurrenceRule) expand(periodo, start time.Time) []time.Time {
+	var candidatos []time.Time
+
+	switch r.Freq {
+	case "DAILY":
+		if r.matchesMonth(periodo) && r.matchesMonthDay(periodo) && r.matchesWeekday(periodo) {
+			candidatos = append(candidatos, periodo)
+		}
+	case "WEEKLY":
+		for i := 0; i < 7; i++ {
+			dia := periodo.AddDate(0, 0, i)
+			if len(r.ByDay) > 0 {
+				if !r.matchesWeekday(dia) {
+					continue
+				}
+			} else if dia.Weekday() != start.Weekday() {
+				continue
+			}
+			if r.matchesMonth(dia) {
+				candidatos = append(candidatos, dia)
+			}
+		}
+	case "MONTHLY":
+		if r.matchesMonth(periodo) {
+			candidatos = r.monthCandidates(periodo.Year(), periodo.Month(), start)
+		}
+	case "YEARLY":
+		if len(r.ByMonth) > 0 {
+			for _, mes := range r.ByMonth {
+				candidatos = append(candidatos, r.monthCandidates(periodo.Year(), time.Month(mes), start)...)
+			}
+		} else if len(r.ByDay) > 0 || len(r.ByMonthDay) > 0 {
+			candidatos = r.yearCandidates(periodo.Year())
+		} else {
+			dia := time.Date(periodo.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+			if dia.Day() == start.Day() {
+				candidatos = append(candidatos, dia)
+			}
+		}
+	}
+
+	sort.Slice(candidatos, func(i, j int) bool {
+		return candidatos[i].Before(candidatos[j])
+	})
+
+	return candidatos
+}
+
+func (r *RecurrenceRule) monthCandidates(ano int, mes time.Month, start time.Time) []time.Time {
+	ultimo := time.Date(ano, mes+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	var candidatos []time.Time
+
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		if start.Day() <= ultimo {
+			candidatos = append(candidatos, time.Date(ano, mes, start.Day(), 0, 0, 0, 0, time.UTC))
+		}
+		return candidatos
+	}
+
+	for d := 1; d <= ultimo; d++ {
+		dia := time.Date(ano, mes, d, 0, 0, 0, 0, time.UTC)
+		if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(dia) {
+			continue
+		}
+		if len(r.ByDay) > 0 && !matchesWeekdayOrdinal(r.ByDay, dia, d, ultimo) {
+			continue
+		}
+		candidatos = append(candidatos, dia)
+	}
+
+	return candidatos
+}
+
+func (r *RecurrenceRule) yearCandidates(ano int) []time.Time {
+	inicio := time.Date(ano, 1, 1, 0, 0, 0, 0, time.UTC)
+	total := time.Date(ano, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	var candidatos []time.Time
+
+	for i := 0; i < total; i++ {
+		dia := inicio.AddDate(0, 0, i)
+		if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(dia) {
+			continue
+		}
+		if len(r.ByDay) > 0 && !matchesWeekdayOrdinal(r.ByDay, dia, i+1, total) {
+			continue
+		}
+		candidatos = append(candidatos, dia)
+	}
+
+	return candidatos
+}
+
+func matchesWeekdayOrdinal(regras []WeekdayRule, dia time.Time, posicao, total int) bool {
+	for _, regra := range regras {
+		if dia.Weekday() != regra.Weekday {
+			continue
+		}
+
+		switch {
+		case regra.Ordinal == 0:
+			return true
+		case regra.Ordinal > 0 && (posicao-1)/7+1 == regra.Ordinal:
+			return true
+		case regra.Ordinal < 0 && (total-posicao)/7+1 == -regra.Ordinal:
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *RecurrenceRule) matchesMonth(dia time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, mes := range r.ByMonth {
+		if time.Month(mes) == dia.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RecurrenceRule) matchesMonthDay(dia time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+
+	ultimo := time.Date(dia.Year(), dia.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, d := range r.ByMonthDay {
+		if d == dia.Day() || (d < 0 && ultimo+d+1 == dia.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RecurrenceRule) matchesWeekday(dia time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, regra := range r.ByDay {
+		if regra.Weekday == dia.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func applySetPos(candidatos []time.Time, posicoes []int) []time.Time {
+	if len(posicoes) == 0 || len(candidatos) == 0 {
+		return candidatos
+	}
+
+	selecionados := make([]time.Time, 0, len(posicoes))
+	for _, pos := range posicoes {
+		idx := pos - 1
+		if pos < 0 {
+			idx = len(candidatos) + pos
+		}
+		if idx >= 0 && idx < len(candidatos) {
+			selecionados = append(selecionados, candidatos[idx])
+		}
+	}
+
+	sort.Slice(selecionados, func(i, j int) bool {
+		return selecionados[i].Before(selecionados[j])
+	})
+
+	return selecionados
+}
+
+func taskRecurrence(tarefa Task) (*RecurrenceRule, error) {
+	rule, err := ParseRecurrenceRule(tarefa.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.Start.IsZero() && tarefa.ValidFrom != "" {
+		start, err := time.Parse("2006-01-02", tarefa.ValidFrom)
+		if err != nil {
+			return nil, fmt.Errorf("data de início de validade inválida: %v", err)
+		}
+		rule.Start = start
+	}
+
+	if rule.Start.IsZero() && rule.needsStart() {
+		return nil, fmt.Errorf("a regra de recorrência exige uma data inicial (DTSTART ou início de validade)")
+	}
+
+	return rule, nil
+}
+
+func (t *TeamworkAPI) schedulableTasks(tarefas []Task) []Task {
+	validas := make([]Task, 0, len(tarefas))
+	for _, tarefa := range tarefas {
+		if tarefa.Recurrence != "" {
+			if _, err := taskRecurrence(tarefa); err != nil {
+				t.logDebug("Regra de recorrência inválida na tarefa %d, ignorando no plano: %v", tarefa.TaskID, err)
+				continue
+			}
+		}
+		validas = append(validas, tarefa)
+	}
+	return validas
+}
+
+func ValidateTaskSchedule(tarefa Task) error {
+	var from, until time.Time
+	var err error
+
+	if tarefa.ValidFrom != "" {
+		if from, err = time.Parse("2006-01-02", tarefa.ValidFrom); err != nil {
+			return fmt.Errorf("tarefa %d: data de início de validade inválida: %v", tarefa.TaskID, err)
+		}
+	}
+
+	if tarefa.ValidUntil != "" {
+		if until, err = time.Parse("2006-01-02", tarefa.ValidUntil); err != nil {
+			return fmt.Errorf("tarefa %d: data de fim de validade inválida: %v", tarefa.TaskID, err)
+		}
+	}
+
+	if !from.IsZero() && !until.IsZero() && until.Before(from) {
+		return fmt.Errorf("tarefa %d: o fim da validade deve ser igual ou posterior ao início", tarefa.TaskID)
+	}
+
+	if tarefa.Recurrence != "" {
+		if _, err := taskRecurrence(tarefa); err != nil {
+			return fmt.Errorf("tarefa %d: %v", tarefa.TaskID, err)
+		}
+	}
+
+	return nil
+}
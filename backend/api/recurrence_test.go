@@ -0,0 +1,157 @@
+package api
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func mustDate(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		t.Fatalf("data inválida no teste: %s", value)
+	}
+	return parsed
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	casos := []struct {
+		nome   string
+		regra  string
+		inicio string
+		fim    string
+		datas  []string
+	}{
+		{
+			nome:   "semanal BYDAY",
+			regra:  "FREQ=WEEKLY;BYDAY=MO,WE",
+			inicio: "2026-10-01",
+			fim:    "2026-10-14",
+			datas:  []string{"2026-10-05", "2026-10-07", "2026-10-12", "2026-10-14"},
+		},
+		{
+			nome:   "mensal BYMONTHDAY com último dia",
+			regra:  "FREQ=MONTHLY;BYMONTHDAY=15,-1",
+			inicio: "2026-01-01",
+			fim:    "2026-03-31",
+			datas:  []string{"2026-01-15", "2026-01-31", "2026-02-15", "2026-02-28", "2026-03-15", "2026-03-31"},
+		},
+		{
+			nome:   "quinzenal com INTERVAL",
+			regra:  "DTSTART:20261002\nRRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=FR",
+			inicio: "2026-10-01",
+			fim:    "2026-10-31",
+			datas:  []string{"2026-10-02", "2026-10-16", "2026-10-30"},
+		},
+		{
+			nome:   "diária com UNTIL",
+			regra:  "DTSTART:20261001\nRRULE:FREQ=DAILY;UNTIL=20261005",
+			inicio: "2026-10-01",
+			fim:    "2026-10-10",
+			datas:  []string{"2026-10-01", "2026-10-02", "2026-10-03", "2026-10-04", "2026-10-05"},
+		},
+		{
+			nome:   "diária com COUNT",
+			regra:  "DTSTART:20261001\nRRULE:FREQ=DAILY;COUNT=3",
+			inicio: "2026-09-01",
+			fim:    "2026-10-31",
+			datas:  []string{"2026-10-01", "2026-10-02", "2026-10-03"},
+		},
+		{
+			nome:   "COUNT conta ocorrências antes da janela",
+			regra:  "DTSTART:20261001\nRRULE:FREQ=WEEKLY;COUNT=4;BYDAY=MO,TH",
+			inicio: "2026-10-06",
+			fim:    "2026-10-31",
+			datas:  []string{"2026-10-08", "2026-10-12"},
+		},
+		{
+			nome:   "última sexta do mês",
+			regra:  "FREQ=MONTHLY;BYDAY=-1FR",
+			inicio: "2026-10-01",
+			fim:    "2026-11-30",
+			datas:  []string{"2026-10-30", "2026-11-27"},
+		},
+		{
+			nome:   "segunda terça do mês",
+			regra:  "FREQ=MONTHLY;BYDAY=2TU",
+			inicio: "2026-10-01",
+			fim:    "2026-10-31",
+			datas:  []string{"2026-10-13"},
+		},
+		{
+			nome:   "último dia útil com BYSETPOS",
+			regra:  "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			inicio: "2026-10-01",
+			fim:    "2026-10-31",
+			datas:  []string{"2026-10-30"},
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(caso.regra)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			ocorrencias := rule.Occurrences(mustDate(t, caso.inicio), mustDate(t, caso.fim))
+			datas := make([]string, 0, len(ocorrencias))
+			for data := range ocorrencias {
+				datas = append(datas, data)
+			}
+			sort.Strings(datas)
+
+			if !reflect.DeepEqual(datas, caso.datas) {
+				t.Errorf("ocorrências = %v, esperado %v", datas, caso.datas)
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceRuleInvalida(t *testing.T) {
+	casos := []struct {
+		nome  string
+		regra string
+	}{
+		{"vazia", ""},
+		{"sem FREQ", "BYDAY=MO"},
+		{"frequência não suportada", "FREQ=HOURLY"},
+		{"INTERVAL zero", "FREQ=DAILY;INTERVAL=0"},
+		{"COUNT negativo", "FREQ=DAILY;COUNT=-1"},
+		{"COUNT com UNTIL", "FREQ=DAILY;COUNT=2;UNTIL=20261010"},
+		{"UNTIL inválido", "FREQ=DAILY;UNTIL=ontem"},
+		{"BYDAY inválido", "FREQ=WEEKLY;BYDAY=XX"},
+		{"ordinal zero", "FREQ=MONTHLY;BYDAY=0MO"},
+		{"BYMONTHDAY fora do intervalo", "FREQ=MONTHLY;BYMONTHDAY=32"},
+		{"parâmetro desconhecido", "FREQ=DAILY;BYHOUR=9"},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if _, err := ParseRecurrenceRule(caso.regra); err == nil {
+				t.Errorf("esperado erro para %q", caso.regra)
+			}
+		})
+	}
+}
+
+func TestTaskRecurrenceExigeInicio(t *testing.T) {
+	tarefa := Task{TaskID: 1, Recurrence: "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR"}
+	if err := ValidateTaskSchedule(tarefa); err == nil {
+		t.Fatal("esperado erro sem data inicial")
+	}
+
+	tarefa.ValidFrom = "2026-10-02"
+	if err := ValidateTaskSchedule(tarefa); err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if !taskAppliesOn(tarefa, mustDate(t, "2026-10-16")) {
+		t.Error("esperado que a tarefa se aplique em 2026-10-16")
+	}
+	if taskAppliesOn(tarefa, mustDate(t, "2026-10-09")) {
+		t.Error("não esperado que a tarefa se aplique em 2026-10-09")
+	}
+}
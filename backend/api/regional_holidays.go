@@ -0,0 +1,405 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type HolidayCalendarSettings struct {
+	State           string    `json:"state,omitempty"`
+	Municipality    string    `json:"municipality,omitempty"`
+	CompanyHolidays []Holiday `json:"companyHolidays,omitempty"`
+	WorkOverrides   []string  `json:"workOverrides,omitempty"`
+	RefreshDays     int       `json:"refreshDays,omitempty"`
+}
+
+type HolidayRegion struct {
+	State          string   `json:"state"`
+	Name           string   `json:"name"`
+	Municipalities []string `json:"municipalities"`
+}
+
+type regionalHoliday struct {
+	month        int
+	day          int
+	easterOffset int
+	movable      bool
+	name         string
+}
+
+type municipalCalendar struct {
+	name     string
+	holidays []regionalHoliday
+}
+
+func fixedDay(month, day int, name string) regionalHoliday {
+	return regionalHoliday{month: month, day: day, name: name}
+}
+
+func easterDay(offset int, name string) regionalHoliday {
+	return regionalHoliday{easterOffset: offset, movable: true, name: name}
+}
+
+var stateNames = map[string]string{
+	"AC": "Acre",
+	"AL": "Alagoas",
+	"AP": "Amapá",
+	"AM": "Amazonas",
+	"BA": "Bahia",
+	"CE": "Ceará",
+	"DF": "Distrito Federal",
+	"ES": "Espírito Santo",
+	"GO": "Goiás",
+	"MA": "Maranhão",
+	"MT": "Mato Grosso",
+	"MS": "Mato Grosso do Sul",
+	"MG": "Minas Gerais",
+	"PA": "Pará",
+	"PB": "Paraíba",
+	"PR": "Paraná",
+	"PE": "Pernambuco",
+	"PI": "Piauí",
+	"RJ": "Rio de Janeiro",
+	"RN": "Rio Grande do Norte",
+	"RS": "Rio Grande do Sul",
+	"RO": "Rondônia",
+	"RR": "Roraima",
+	"SC": "Santa Catarina",
+	"SP": "São Paulo",
+	"SE": "Sergipe",
+	"TO": "Tocantins",
+}
+
+var stateHolidayData = map[string][]regionalHoliday{
+	"AC": {
+		fixedDay(1, 23, "Dia do Evangélico"),
+		fixedDay(6, 15, "Aniversário do Acre"),
+		fixedDay(9, 5, "Dia da Amazônia"),
+		fixedDay(11, 17, "Assinatura do Tratado de Petrópolis"),
+	},
+	"AL": {
+		fixedDay(6, 24, "São João"),
+		fixedDay(6, 29, "São Pedro"),
+		fixedDay(9, 16, "Emancipação Política de Alagoas"),
+	},
+	"AP": {
+		fixedDay(3, 19, "São José"),
+		fixedDay(7, 25, "São Tiago"),
+		fixedDay(10, 5, "Criação do Estado do Amapá"),
+	},
+	"AM": {
+		fixedDay(9, 5, "Elevação do Amazonas à Categoria de Província"),
+	},
+	"BA": {
+		fixedDay(7, 2, "Independência da Bahia"),
+	},
+	"CE": {
+		fixedDay(3, 19, "São José"),
+		fixedDay(3, 25, "Data Magna do Ceará"),
+	},
+	"DF": {
+		fixedDay(11, 30, "Dia do Evangélico"),
+	},
+	"MA": {
+		fixedDay(7, 28, "Adesão do Maranhão à Independência"),
+	},
+	"MS": {
+		fixedDay(10, 11, "Criação do Estado de Mato Grosso do Sul"),
+	},
+	"PA": {
+		fixedDay(8, 15, "Adesão do Grão-Pará à Independência"),
+	},
+	"PB": {
+		fixedDay(8, 5, "Fundação do Estado da Paraíba"),
+	},
+	"PR": {
+		fixedDay(12, 19, "Emancipação Política do Paraná"),
+	},
+	"PE": {
+		fixedDay(3, 6, "Revolução Pernambucana"),
+		fixedDay(6, 24, "São João"),
+	},
+	"PI": {
+		fixedDay(3, 13, "Batalha do Jenipapo"),
+		fixedDay(10, 19, "Dia do Piauí"),
+	},
+	"RJ": {
+		fixedDay(4, 23, "São Jorge"),
+	},
+	"RN": {
+		fixedDay(10, 3, "Mártires de Cunhaú e Uruaçu"),
+	},
+	"RS": {
+		fixedDay(9, 20, "Revolução Farroupilha"),
+	},
+	"RO": {
+		fixedDay(1, 4, "Criação do Estado de Rondônia"),
+		fixedDay(6, 18, "Dia do Evangélico"),
+	},
+	"RR": {
+		fixedDay(10, 5, "Criação do Estado de Roraima"),
+	},
+	"SC": {
+		fixedDay(8, 11, "Data Magna de Santa Catarina"),
+	},
+	"SP": {
+		fixedDay(7, 9, "Revolução Constitucionalista"),
+	},
+	"SE": {
+		fixedDay(7, 8, "Emancipação Política de Sergipe"),
+	},
+	"TO": {
+		fixedDay(3, 18, "Autonomia do Tocantins"),
+		fixedDay(9, 8, "Nossa Senhora da Natividade"),
+		fixedDay(10, 5, "Criação do Estado do Tocantins"),
+	},
+}
+
+var municipalHolidayData = map[string][]municipalCalendar{
+	"AL": {{"Maceió", []regionalHoliday{
+		fixedDay(8, 27, "Nossa Senhora dos Prazeres"),
+		fixedDay(12, 8, "Nossa Senhora da Conceição"),
+	}}},
+	"AM": {{"Manaus", []regionalHoliday{
+		fixedDay(10, 24, "Aniversário de Manaus"),
+		fixedDay(12, 8, "Nossa Senhora da Conceição"),
+	}}},
+	"BA": {{"Salvador", []regionalHoliday{
+		fixedDay(12, 8, "Nossa Senhora da Conceição da Praia"),
+	}}},
+	"CE": {{"Fortaleza", []regionalHoliday{
+		fixedDay(8, 15, "Nossa Senhora da Assunção"),
+	}}},
+	"ES": {{"Vitória", []regionalHoliday{
+		easterDay(8, "Nossa Senhora da Penha"),
+		fixedDay(9, 8, "Nossa Senhora da Vitória"),
+	}}},
+	"GO": {{"Goiânia", []regionalHoliday{
+		fixedDay(5, 24, "Nossa Senhora Auxiliadora"),
+		fixedDay(10, 24, "Aniversário de Goiânia"),
+	}}},
+	"MA": {{"São Luís", []regionalHoliday{
+		fixedDay(9, 8, "Aniversário de São Luís"),
+	}}},
+	"MG": {{"Belo Horizonte", []regionalHoliday{
+		fixedDay(8, 15, "Assunção de Nossa Senhora"),
+		fixedDay(12, 8, "Imaculada Conceição"),
+	}}},
+	"MS": {{"Campo Grande", []regionalHoliday{
+		fixedDay(8, 26, "Aniversário de Campo Grande"),
+	}}},
+	"MT": {{"Cuiabá", []regionalHoliday{
+		fixedDay(4, 8, "Aniversário de Cuiabá"),
+	}}},
+	"PA": {{"Belém", []regionalHoliday{
+		fixedDay(1, 12, "Aniversário de Belém"),
+	}}},
+	"PB": {{"João Pessoa", []regionalHoliday{
+		fixedDay(8, 5, "Nossa Senhora das Neves"),
+	}}},
+	"PE": {{"Recife", []regionalHoliday{
+		fixedDay(7, 16, "Nossa Senhora do Carmo"),
+		fixedDay(12, 8, "Nossa Senhora da Conceição"),
+	}}},
+	"PI": {{"Teresina", []regionalHoliday{
+		fixedDay(8, 16, "Aniversário de Teresina"),
+	}}},
+	"PR": {{"Curitiba", []regionalHoliday{
+		fixedDay(9, 8, "Nossa Senhora da Luz dos Pinhais"),
+	}}},
+	"RJ": {{"Rio de Janeiro", []regionalHoliday{
+		fixedDay(1, 20, "São Sebastião"),
+	}}},
+	"RN": {{"Natal", []regionalHoliday{
+		fixedDay(1, 6, "Santos Reis"),
+		fixedDay(11, 21, "Nossa Senhora da Apresentação"),
+	}}},
+	"RS": {{"Porto Alegre", []regionalHoliday{
+		fixedDay(2, 2, "Nossa Senhora dos Navegantes"),
+	}}},
+	"SC": {{"Florianópolis", []regionalHoliday{
+		fixedDay(3, 23, "Aniversário de Florianópolis"),
+	}}},
+	"SE": {{"Aracaju", []regionalHoliday{
+		fixedDay(3, 17, "Aniversário de Aracaju"),
+		fixedDay(12, 8, "Nossa Senhora da Conceição"),
+	}}},
+	"SP": {
+		{"São Paulo", []regionalHoliday{
+			fixedDay(1, 25, "Aniversário de São Paulo"),
+			easterDay(60, "Corpus Christi"),
+		}},
+		{"Campinas", []regionalHoliday{
+			easterDay(60, "Corpus Christi"),
+			fixedDay(12, 8, "Nossa Senhora da Conceição"),
+		}},
+	},
+	"TO": {{"Palmas", []regionalHoliday{
+		fixedDay(5, 20, "Aniversário de Palmas"),
+	}}},
+}
+
+func GetHolidayRegions() []HolidayRegion {
+	regions := make([]HolidayRegion, 0, len(stateNames))
+	for uf, name := range stateNames {
+		region := HolidayRegion{State: uf, Name: name, Municipalities: []string{}}
+		for _, calendar := range municipalHolidayData[uf] {
+			region.Municipalities = append(region.Municipalities, calendar.name)
+		}
+		sort.Strings(region.Municipalities)
+		regions = append(regions, region)
+	}
+
+	sort.Slice(regions, func(i, j int) bool {
+		return regions[i].State < regions[j].State
+	})
+	return regions
+}
+
+func ValidateHolidayCalendarSettings(settings HolidayCalendarSettings) error {
+	uf := strings.ToUpper(strings.TrimSpace(settings.State))
+	if uf != "" {
+		if _, ok := stateNames[uf]; !ok {
+			return fmt.Errorf("UF inválida: %s", settings.State)
+		}
+	}
+
+	if strings.TrimSpace(settings.Municipality) != "" {
+		if uf == "" {
+			return fmt.Errorf("informe a UF do município %s", settings.Municipality)
+		}
+		if findMunicipalCalendar(uf, settings.Municipality) == nil {
+			return fmt.Errorf("município sem calendário disponível em %s: %s (cadastre os feriados como feriados da empresa)", uf, settings.Municipality)
+		}
+	}
+
+	for _, holiday := range settings.CompanyHolidays {
+		if strings.TrimSpace(holiday.Name) == "" {
+			return fmt.Errorf("feriado da empresa sem nome em %s", holiday.Date)
+		}
+		if _, _, _, ok := parseCompanyHolidayDate(holiday.Date); !ok {
+			return fmt.Errorf("data inválida para o feriado %s: %s (use AAAA-MM-DD ou MM-DD)", holiday.Name, holiday.Date)
+		}
+		if holiday.WorkFraction < 0 || holiday.WorkFraction >= 1 {
+			return fmt.Errorf("fração de jornada inválida para o feriado %s: %.2f (use 0 para folga integral ou um valor entre 0 e 1)", holiday.Name, holiday.WorkFraction)
+		}
+	}
+
+	if settings.RefreshDays < -1 {
+		return fmt.Errorf("intervalo de atualização de feriados inválido: %d (use -1 para nunca consultar a API)", settings.RefreshDays)
+	}
+
+	for _, date := range settings.WorkOverrides {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return fmt.Errorf("data inválida na lista de feriados trabalhados: %s", date)
+		}
+	}
+
+	return nil
+}
+
+func findMunicipalCalendar(uf, municipality string) *municipalCalendar {
+	nome := normalizeText(strings.TrimSpace(municipality))
+	for i, calendar := range municipalHolidayData[uf] {
+		if normalizeText(calendar.name) == nome {
+			return &municipalHolidayData[uf][i]
+		}
+	}
+	return nil
+}
+
+func parseCompanyHolidayDate(value string) (month, day, year int, ok bool) {
+	value = strings.TrimSpace(value)
+	if parsed, err := time.Parse("2006-01-02", value); err == nil {
+		return int(parsed.Month()), parsed.Day(), parsed.Year(), true
+	}
+
+	if parsed, err := time.Parse("01-02", value); err == nil {
+		return int(parsed.Month()), parsed.Day(), 0, true
+	}
+
+	return 0, 0, 0, false
+}
+
+func companyHolidayDate(value string, year int) (string, bool) {
+	month, day, holidayYear, ok := parseCompanyHolidayDate(value)
+	if !ok || (holidayYear != 0 && holidayYear != year) {
+		return "", false
+	}
+
+	return fmt.Sprintf("%d-%02d-%02d", year, month, day), true
+}
+
+func (t *TeamworkAPI) SetHolidayCalendar(settings HolidayCalendarSettings) {
+	t.settingsMutex.Lock()
+	defer t.settingsMutex.Unlock()
+	t.holidayCalendar = settings
+}
+
+func (t *TeamworkAPI) currentHolidayCalendar() HolidayCalendarSettings {
+	t.settingsMutex.RLock()
+	defer t.settingsMutex.RUnlock()
+	return t.holidayCalendar
+}
+
+func (t *TeamworkAPI) GetHolidayCalendar(year int) (map[string]Holiday, error) {
+	national, err := t.GetBrazilianHolidays(year)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := make(map[string]Holiday, len(national))
+	for dateStr, holiday := range national {
+		holidays[dateStr] = holiday
+	}
+
+	settings := t.currentHolidayCalendar()
+	uf := strings.ToUpper(strings.TrimSpace(settings.State))
+	pascoa := easterSunday(year)
+
+	addRegionalHolidays(holidays, stateHolidayData[uf], year, pascoa, "estadual")
+	if calendar := findMunicipalCalendar(uf, settings.Municipality); calendar != nil {
+		addRegionalHolidays(holidays, calendar.holidays, year, pascoa, "municipal")
+	}
+
+	for _, company := range settings.CompanyHolidays {
+		dateStr, ok := companyHolidayDate(company.Date, year)
+		if !ok {
+			continue
+		}
+
+		company.Date = dateStr
+		company.Type = "empresa"
+		addCalendarHoliday(holidays, company)
+	}
+
+	for _, date := range settings.WorkOverrides {
+		delete(holidays, date)
+	}
+
+	return holidays, nil
+}
+
+func addRegionalHolidays(holidays map[string]Holiday, regional []regionalHoliday, year int, pascoa time.Time, holidayType string) {
+	for _, item := range regional {
+		data := time.Date(year, time.Month(item.month), item.day, 0, 0, 0, 0, time.Local)
+		if item.movable {
+			data = pascoa.AddDate(0, 0, item.easterOffset)
+		}
+
+		addCalendarHoliday(holidays, Holiday{
+			Date: formatDate(data),
+			Name: item.name,
+			Type: holidayType,
+		})
+	}
+}
+
+func addCalendarHoliday(holidays map[string]Holiday, holiday Holiday) {
+	if existing, exists := holidays[holiday.Date]; exists && !existing.IsOptional {
+		return
+	}
+	holidays[holiday.Date] = holiday
+}
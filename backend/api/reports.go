@@ -63,24 +63,55 @@ type LoggedTimeResponse struct {
 	} `json:"user"`
 }
 
-func (t *TeamworkAPI) GetDefaultReportPath() (string, error) {
+func getReportsDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("erro ao obter diretório do usuário: %v", err)
 	}
 
-	now := time.Now()
-	monthYear := now.Format("2006-01")
-
 	reportsDir := filepath.Join(homeDir, "TeamworkReports")
 	if err := os.MkdirAll(reportsDir, 0755); err != nil {
 		return "", fmt.Errorf("erro ao criar diretório de relatórios: %v", err)
 	}
 
+	return reportsDir, nil
+}
+
+func (t *TeamworkAPI) GetDefaultReportPath() (string, error) {
+	reportsDir, err := getReportsDir()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	monthYear := now.Format("2006-01")
+
 	fileName := fmt.Sprintf("TeamworkReport_%s.pdf", monthYear)
 	return filepath.Join(reportsDir, fileName), nil
 }
 
+func writeFileAtomically(filePath string, data []byte) error {
+	dir := filepath.Dir(filePath)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("erro ao criar diretório: %v", err)
+		}
+	}
+
+	tempFile := filePath + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("erro ao criar arquivo temporário: %v", err)
+	}
+
+	if err := os.Rename(tempFile, filePath); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("erro ao mover arquivo para destino final: %v", err)
+	}
+
+	return nil
+}
+
 func (t *TeamworkAPI) GetTimeEntriesForPeriod(startDate, endDate string) ([]TimeEntryReport, error) {
 	if !t.IsConfigured() {
 		return nil, fmt.Errorf("API não configurada")
@@ -144,6 +175,7 @@ func (t *TeamworkAPI) GetTimeEntriesForPeriod(startDate, endDate string) ([]Time
 		page++
 	}
 
+	t.resolveEntryTags(allEntries)
 	return allEntries, nil
 }
 
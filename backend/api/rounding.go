@@ -0,0 +1,206 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+type RoundingPolicy struct {
+	Mode      string `json:"mode"`
+	Increment int    `json:"increment,omitempty"`
+	Minimum   int    `json:"minimum,omitempty"`
+}
+
+func ValidateRoundingPolicy(policy RoundingPolicy) error {
+	switch strings.ToLower(strings.TrimSpace(policy.Mode)) {
+	case "", "none", "nearest", "up", "down":
+	default:
+		return fmt.Errorf("modo de arredondamento inválido: %s (use nearest, up ou down)", policy.Mode)
+	}
+
+	if policy.Increment < 0 || policy.Increment > 24*60 {
+		return fmt.Errorf("incremento de arredondamento inválido: %d", policy.Increment)
+	}
+
+	if policy.Minimum < 0 || policy.Minimum > 24*60 {
+		return fmt.Errorf("mínimo de arredondamento inválido: %d", policy.Minimum)
+	}
+
+	return nil
+}
+
+func ValidateRoundingConfig(config Config) error {
+	if err := ValidateRoundingPolicy(config.Rounding); err != nil {
+		return err
+	}
+
+	for projectID, policy := range config.ProjectRounding {
+		if err := ValidateRoundingPolicy(policy); err != nil {
+			return fmt.Errorf("projeto %d: %v", projectID, err)
+		}
+	}
+
+	return nil
+}
+
+func ValidateTaskRounding(tarefa Task) error {
+	if tarefa.Rounding == nil {
+		return nil
+	}
+	if err := ValidateRoundingPolicy(*tarefa.Rounding); err != nil {
+		return fmt.Errorf("tarefa %d: %v", tarefa.TaskID, err)
+	}
+	return nil
+}
+
+func (p RoundingPolicy) Active() bool {
+	mode := strings.ToLower(strings.TrimSpace(p.Mode))
+	return mode != "" && mode != "none"
+}
+
+func (p RoundingPolicy) step() int {
+	if p.Increment <= 0 {
+		return 1
+	}
+	return p.Increment
+}
+
+func (p RoundingPolicy) Apply(minutes int) int {
+	if !p.Active() || minutes <= 0 {
+		return minutes
+	}
+
+	incremento := p.step()
+	arredondado := minutes
+	switch strings.ToLower(strings.TrimSpace(p.Mode)) {
+	case "nearest":
+		arredondado = (minutes + incremento/2) / incremento * incremento
+	case "up":
+		arredondado = (minutes + incremento - 1) / incremento * incremento
+	case "down":
+		arredondado = minutes / incremento * incremento
+	}
+
+	if arredondado == 0 {
+		arredondado = incremento
+	}
+
+	if arredondado < p.Minimum {
+		arredondado = p.Minimum
+	}
+
+	return arredondado
+}
+
+func (t *TeamworkAPI) SetSavedTasks(tasks []Task) {
+	savedTasks := make(map[int]Task, len(tasks))
+	for _, task := range tasks {
+		savedTasks[task.TaskID] = task
+	}
+
+	t.settingsMutex.Lock()
+	defer t.settingsMutex.Unlock()
+	t.savedTasks = savedTasks
+}
+
+func (t *TeamworkAPI) currentSavedTasks() map[int]Task {
+	t.settingsMutex.RLock()
+	defer t.settingsMutex.RUnlock()
+	return t.savedTasks
+}
+
+func (t *TeamworkAPI) roundingPolicyFor(taskID, projectID int, tarefa *Task) RoundingPolicy {
+	if tarefa != nil && tarefa.Rounding != nil {
+		return *tarefa.Rounding
+	}
+
+	saved, ok := t.currentSavedTasks()[taskID]
+	if ok && saved.Rounding != nil {
+		return *saved.Rounding
+	}
+
+	if projectID == 0 && tarefa != nil {
+		projectID = tarefa.ProjectID
+	}
+	if projectID == 0 && ok {
+		projectID = saved.ProjectID
+	}
+
+	if policy, found := t.Config.ProjectRounding[projectID]; found && projectID > 0 {
+		return policy
+	}
+
+	return t.Config.Rounding
+}
+
+func (t *TeamworkAPI) roundLoggedMinutes(taskID, minutes int) int {
+	projectID := 0
+	if _, saved := t.currentSavedTasks()[taskID]; !saved && len(t.Config.ProjectRounding) > 0 {
+		if task, err := t.GetTaskDetails(taskID); err == nil {
+			projectID = task.ProjectID
+		}
+	}
+
+	policy := t.roundingPolicyFor(taskID, projectID, nil)
+	arredondado := policy.Apply(minutes)
+	if arredondado != minutes {
+		t.logDebug("Arredondando lançamento da tarefa #%d de %d para %d minutos", taskID, minutes, arredondado)
+	}
+	return arredondado
+}
+
+func (t *TeamworkAPI) roundUpdatedMinutes(entryID, minutes int) int {
+	if !t.hasScopedRounding() {
+		return t.Config.Rounding.Apply(minutes)
+	}
+
+	info, err := t.fetchTimeEntryInfo(entryID)
+	if err != nil {
+		t.logDebug("Não foi possível obter a tarefa da entrada %d para arredondamento: %v", entryID, err)
+		return t.Config.Rounding.Apply(minutes)
+	}
+
+	return t.roundingPolicyFor(info.TaskID, info.ProjectID, nil).Apply(minutes)
+}
+
+func (t *TeamworkAPI) hasScopedRounding() bool {
+	if len(t.Config.ProjectRounding) > 0 {
+		return true
+	}
+
+	for _, task := range t.currentSavedTasks() {
+		if task.Rounding != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *TeamworkAPI) newPlanEntry(tarefa Task, entrada TimeEntry) EntryTask {
+	alocacao := EntryTask{
+		TaskID: tarefa.TaskID,
+		Entry:  entrada,
+	}
+
+	arredondado := t.roundingPolicyFor(tarefa.TaskID, 0, &tarefa).Apply(entrada.Minutes)
+	if arredondado != entrada.Minutes {
+		alocacao.OriginalMinutes = entrada.Minutes
+		alocacao.Entry.Minutes = arredondado
+	}
+
+	return alocacao
+}
+
+func (t *TeamworkAPI) importPlanEntry(taskID int, entrada TimeEntry) EntryTask {
+	tarefa, saved := t.currentSavedTasks()[taskID]
+	if !saved {
+		tarefa = Task{TaskID: taskID}
+		if len(t.Config.ProjectRounding) > 0 {
+			if task, err := t.GetTaskDetails(taskID); err == nil {
+				tarefa.ProjectID = task.ProjectID
+			}
+		}
+	}
+
+	return t.newPlanEntry(tarefa, entrada)
+}
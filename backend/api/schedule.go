@@ -0,0 +1,120 @@
+package api
+
+import "fmt"
+
+const fimDoDia = 24 * 60
+
+func (t *TeamworkAPI) dayStartMinutes() int {
+	if t.Config.Schedule.DayStart == "" {
+		return defaultDayStart
+	}
+
+	inicio, err := parseClock(t.Config.Schedule.DayStart)
+	if err != nil {
+		t.logDebug("Horário de início do dia inválido (%s), usando padrão: %v", t.Config.Schedule.DayStart, err)
+		return defaultDayStart
+	}
+
+	return inicio
+}
+
+func (t *TeamworkAPI) lunchWindow() (int, int, bool) {
+	schedule := t.Config.Schedule
+	if schedule.LunchStart == "" || schedule.LunchMinutes <= 0 {
+		return 0, 0, false
+	}
+
+	inicio, err := parseClock(schedule.LunchStart)
+	if err != nil {
+		t.logDebug("Horário de almoço inválido (%s), ignorando intervalo: %v", schedule.LunchStart, err)
+		return 0, 0, false
+	}
+
+	return inicio, inicio + schedule.LunchMinutes, true
+}
+
+func ValidateDaySchedule(schedule DaySchedule) error {
+	if schedule.DayStart != "" {
+		if _, err := parseClock(schedule.DayStart); err != nil {
+			return fmt.Errorf("início do dia inválido: %v", err)
+		}
+	}
+
+	if schedule.LunchStart != "" {
+		if _, err := parseClock(schedule.LunchStart); err != nil {
+			return fmt.Errorf("início do almoço inválido: %v", err)
+		}
+	}
+
+	if schedule.LunchMinutes < 0 || schedule.BufferMinutes < 0 {
+		return fmt.Errorf("duração do almoço e intervalos não podem ser negativos")
+	}
+
+	return nil
+}
+
+func (t *TeamworkAPI) layoutWorkDay(workDay *WorkDay, inicio int) {
+	almocoInicio, almocoFim, temAlmoco := t.lunchWindow()
+	intervalo := t.Config.Schedule.BufferMinutes
+
+	cursor := inicio
+	excedente := 0
+	entradas := make([]EntryTask, 0, len(workDay.Entries))
+
+	for _, alocacao := range workDay.Entries {
+		restante := alocacao.Entry.Minutes
+		politica := t.roundingPolicyFor(alocacao.TaskID, 0, nil)
+		primeiro := true
+
+		for restante > 0 {
+			if temAlmoco && cursor >= almocoInicio && cursor < almocoFim {
+				cursor = almocoFim
+			}
+
+			if cursor >= fimDoDia {
+				excedente += restante
+				break
+			}
+
+			parte := restante
+			if cursor+parte > fimDoDia {
+				parte = fimDoDia - cursor
+			}
+			if temAlmoco && cursor < almocoInicio && cursor+parte > almocoInicio {
+				parte = almocoInicio - cursor
+				if politica.Active() {
+					parte = parte / politica.step() * politica.step()
+					if parte == 0 || parte < politica.Minimum || restante-parte < politica.Minimum {
+						cursor = almocoFim
+						continue
+					}
+				}
+			}
+
+			segmento := alocacao
+			segmento.Entry.Minutes = parte
+			segmento.Entry.Time = formatClock(cursor)
+			segmento.OriginalMinutes = 0
+			if primeiro {
+				segmento.OriginalMinutes = alocacao.OriginalMinutes
+				primeiro = false
+			}
+			entradas = append(entradas, segmento)
+
+			cursor += parte
+			restante -= parte
+		}
+
+		cursor += intervalo
+	}
+
+	workDay.Entries = entradas
+	if excedente > 0 {
+		workDay.TotalMin -= excedente
+		workDay.Warnings = append(workDay.Warnings, dayOverflowWarning(excedente))
+	}
+}
+
+func dayOverflowWarning(minutos int) string {
+	return fmt.Sprintf("%s não couberam no dia (após 24:00) e foram deixadas de fora do plano", formatMinutesAsHours(minutos))
+}
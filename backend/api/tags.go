@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	tagsPageSize = 250
+	tagsMaxPages = 40
+)
+
+func (t *TeamworkAPI) GetTags() ([]Tag, error) {
+	cacheKey := "tags"
+	if cachedData, found := t.cache.Get(cacheKey); found {
+		return cachedData.([]Tag), nil
+	}
+
+	if !t.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+
+	var allTags []Tag
+
+	for page := 1; page <= tagsMaxPages; page++ {
+		path := fmt.Sprintf("/projects/api/v3/tags.json?page=%d&pageSize=%d", page, tagsPageSize)
+		url := t.buildURL(path)
+
+		req, err := t.createRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, body, err := t.doRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != 200 {
+			return nil, fmt.Errorf("erro ao obter tags: %d %s - %s",
+				resp.StatusCode, resp.Status, string(body[:minValue(len(body), 100)]))
+		}
+
+		var response struct {
+			Tags []Tag `json:"tags"`
+			Meta struct {
+				Page struct {
+					HasMore bool `json:"hasMore"`
+				} `json:"page"`
+			} `json:"meta"`
+		}
+
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("erro ao decodificar resposta: %v", err)
+		}
+
+		allTags = append(allTags, response.Tags...)
+
+		if !response.Meta.Page.HasMore {
+			t.cache.Set(cacheKey, allTags, 30*time.Minute)
+			return allTags, nil
+		}
+	}
+
+	return nil, fmt.Errorf("tags demais: mais de %d páginas de %d tags", tagsMaxPages, tagsPageSize)
+}
+
+func (t *TeamworkAPI) resolveEntryTags(entries []TimeEntryReport) {
+	needsLookup := false
+	for _, entry := range entries {
+		if len(entry.TagIDs) > 0 && len(entry.Tags) == 0 {
+			needsLookup = true
+			break
+		}
+	}
+
+	if !needsLookup {
+		return
+	}
+
+	tags, err := t.GetTags()
+	if err != nil {
+		t.logDebug("Não foi possível obter tags para as entradas de tempo: %v", err)
+		return
+	}
+
+	tagsByID := make(map[int]Tag, len(tags))
+	for _, tag := range tags {
+		tagsByID[tag.ID] = tag
+	}
+
+	for i := range entries {
+		if len(entries[i].Tags) > 0 {
+			continue
+		}
+		for _, tagID := range entries[i].TagIDs {
+			if tag, ok := tagsByID[tagID]; ok {
+				entries[i].Tags = append(entries[i].Tags, tag)
+			}
+		}
+	}
+}
+
+func MergeTagIDs(base []int, extra []int) []int {
+	if len(extra) == 0 {
+		return base
+	}
+
+	seen := make(map[int]bool, len(base)+len(extra))
+	merged := make([]int, 0, len(base)+len(extra))
+	for _, tagID := range append(append([]int{}, base...), extra...) {
+		if tagID <= 0 || seen[tagID] {
+			continue
+		}
+		seen[tagID] = true
+		merged = append(merged, tagID)
+	}
+
+	return merged
+}
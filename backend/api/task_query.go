@@ -0,0 +1,253 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TaskQuery struct {
+	AssigneeIDs     []int  `json:"assigneeIds,omitempty"`
+	AnyAssignee     bool   `json:"anyAssignee,omitempty"`
+	ProjectIDs      []int  `json:"projectIds,omitempty"`
+	TasklistIDs     []int  `json:"tasklistIds,omitempty"`
+	TagIDs          []int  `json:"tagIds,omitempty"`
+	MatchAllTags    bool   `json:"matchAllTags,omitempty"`
+	Status          string `json:"status,omitempty"`
+	DueAfter        string `json:"dueAfter,omitempty"`
+	DueBefore       string `json:"dueBefore,omitempty"`
+	StartAfter      string `json:"startAfter,omitempty"`
+	StartBefore     string `json:"startBefore,omitempty"`
+	UpdatedAfter    string `json:"updatedAfter,omitempty"`
+	IncludeSubtasks bool   `json:"includeSubtasks,omitempty"`
+	IncludeTime     bool   `json:"includeTime,omitempty"`
+	IncludeDeleted  bool   `json:"includeDeleted,omitempty"`
+	Page            int    `json:"page,omitempty"`
+	PageSize        int    `json:"pageSize,omitempty"`
+}
+
+type TaskQueryResult struct {
+	Tasks    []TeamworkTask `json:"tasks"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"pageSize"`
+	HasMore  bool           `json:"hasMore"`
+}
+
+const (
+	defaultTaskPageSize = 100
+	maxTaskPageSize     = 500
+	maxTaskQueryPages   = 40
+)
+
+func ValidateTaskQuery(query TaskQuery) error {
+	switch query.Status {
+	case "", "active", "completed", "all", "overdue":
+	default:
+		return fmt.Errorf("status de tarefa inválido: %s (use active, completed, all ou overdue)", query.Status)
+	}
+
+	datas := map[string]string{
+		"vencimento inicial": query.DueAfter,
+		"vencimento final":   query.DueBefore,
+		"início inicial":     query.StartAfter,
+		"início final":       query.StartBefore,
+	}
+	for nome, valor := range datas {
+		if valor == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", valor); err != nil {
+			return fmt.Errorf("data de %s inválida: %s", nome, valor)
+		}
+	}
+
+	if query.UpdatedAfter != "" {
+		if _, err := parseQueryTimestamp(query.UpdatedAfter); err != nil {
+			return fmt.Errorf("data de atualização inválida: %s", query.UpdatedAfter)
+		}
+	}
+
+	if query.Page < 0 || query.PageSize < 0 || query.PageSize > maxTaskPageSize {
+		return fmt.Errorf("paginação inválida (página %d, tamanho %d, máximo %d)", query.Page, query.PageSize, maxTaskPageSize)
+	}
+
+	return nil
+}
+
+func parseQueryTimestamp(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
+func (t *TeamworkAPI) taskQueryParams(query TaskQuery) url.Values {
+	params := url.Values{}
+	include := "projects,tasklists,tags"
+	if query.IncludeTime {
+		include += ",time"
+	}
+	params.Set("include", include)
+
+	page := query.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultTaskPageSize
+	}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("pageSize", strconv.Itoa(pageSize))
+
+	switch {
+	case len(query.AssigneeIDs) > 0:
+		params.Set("assigneeUserIds", joinIDs(query.AssigneeIDs))
+	case !query.AnyAssignee && t.Config.UserID > 0:
+		params.Set("assigneeUserIds", strconv.Itoa(t.Config.UserID))
+	}
+
+	if len(query.ProjectIDs) > 0 {
+		params.Set("projectIds", joinIDs(query.ProjectIDs))
+	}
+	if len(query.TasklistIDs) > 0 {
+		params.Set("tasklistIds", joinIDs(query.TasklistIDs))
+	}
+	if len(query.TagIDs) > 0 {
+		params.Set("tagIds", joinIDs(query.TagIDs))
+		if query.MatchAllTags {
+			params.Set("matchAllTags", "true")
+		}
+	}
+
+	switch query.Status {
+	case "active":
+		params.Set("filter", "active")
+	case "completed":
+		params.Set("includeCompletedTasks", "true")
+		params.Set("onlyCompletedTasks", "true")
+	case "all":
+		params.Set("includeCompletedTasks", "true")
+	case "overdue":
+		params.Set("filter", "overdue")
+	}
+
+	if query.DueAfter != "" {
+		params.Set("dueAfter", query.DueAfter)
+	}
+	if query.DueBefore != "" {
+		params.Set("dueBefore", query.DueBefore)
+	}
+	if query.StartAfter != "" {
+		params.Set("startAfter", query.StartAfter)
+	}
+	if query.StartBefore != "" {
+		params.Set("startBefore", query.StartBefore)
+	}
+	if query.UpdatedAfter != "" {
+		if updated, err := parseQueryTimestamp(query.UpdatedAfter); err == nil {
+			params.Set("updatedAfter", updated.UTC().Format(time.RFC3339))
+		}
+	}
+	if query.IncludeSubtasks {
+		params.Set("includeAllSubtasks", "true")
+	}
+	if query.IncludeDeleted {
+		params.Set("showDeleted", "true")
+	}
+
+	return params
+}
+
+func joinIDs(ids []int) string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(id))
+	}
+	return strings.Join(values, ",")
+}
+
+func (t *TeamworkAPI) QueryTasks(query TaskQuery) (*TaskQueryResult, error) {
+	return t.queryTasks(query, true)
+}
+
+func (t *TeamworkAPI) queryTasks(query TaskQuery, useCache bool) (*TaskQueryResult, error) {
+	if err := ValidateTaskQuery(query); err != nil {
+		return nil, err
+	}
+
+	params := t.taskQueryParams(query)
+	cacheKey := "tasks_query_" + params.Encode()
+	if useCache {
+		if cachedData, found := t.cache.Get(cacheKey); found {
+			return cachedData.(*TaskQueryResult), nil
+		}
+	}
+
+	if !t.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+
+	url := t.buildURL("/projects/api/v3/tasks.json?" + params.Encode())
+	t.logDebug("Fazendo requisição para URL: %s", url)
+
+	req, err := t.createRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := t.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("erro ao obter tarefas: %d %s - %s",
+			resp.StatusCode, resp.Status, string(body))
+	}
+
+	tasks, hasMore, err := parseTasksPage(body)
+	if err != nil {
+		return nil, err
+	}
+
+	page, _ := strconv.Atoi(params.Get("page"))
+	pageSize, _ := strconv.Atoi(params.Get("pageSize"))
+	result := &TaskQueryResult{
+		Tasks:    tasks,
+		Page:     page,
+		PageSize: pageSize,
+		HasMore:  hasMore,
+	}
+
+	t.cache.Set(cacheKey, result, 15*time.Minute)
+	return result, nil
+}
+
+func (t *TeamworkAPI) QueryAllTasks(query TaskQuery) ([]TeamworkTask, error) {
+	return t.queryAllTasks(query, true)
+}
+
+func (t *TeamworkAPI) queryAllTasks(query TaskQuery, useCache bool) ([]TeamworkTask, error) {
+	if query.PageSize <= 0 {
+		query.PageSize = defaultTaskPageSize
+	}
+
+	tasks := make([]TeamworkTask, 0)
+	for page := 1; page <= maxTaskQueryPages; page++ {
+		query.Page = page
+		result, err := t.queryTasks(query, useCache)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, result.Tasks...)
+		if !result.HasMore {
+			return tasks, nil
+		}
+	}
+
+	return nil, fmt.Errorf("consulta com tarefas demais: mais de %d páginas de %d tarefas", maxTaskQueryPages, query.PageSize)
+}
@@ -0,0 +1,422 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type TaskSearchResult struct {
+	Task          TeamworkTask `json:"task"`
+	Score         float64      `json:"score"`
+	MatchedFields []string     `json:"matchedFields"`
+	LastUsed      string       `json:"lastUsed,omitempty"`
+}
+
+type TaskUsage struct {
+	LastUsed string `json:"lastUsed"`
+	Count    int    `json:"count"`
+}
+
+type taskSearchDocument struct {
+	task     TeamworkTask
+	fields   map[string][]string
+	searchID string
+}
+
+type taskSearchIndex struct {
+	mutex     sync.RWMutex
+	documents map[int]*taskSearchDocument
+	syncedAt  time.Time
+}
+
+const (
+	taskIndexPageSize    = 250
+	taskIndexRefreshTime = 15 * time.Minute
+	defaultSearchLimit   = 20
+)
+
+var taskSearchFieldWeights = map[string]float64{
+	"name":     3,
+	"tasklist": 1.5,
+	"project":  1.5,
+	"tags":     1,
+}
+
+func newTaskSearchIndex() *taskSearchIndex {
+	return &taskSearchIndex{documents: make(map[int]*taskSearchDocument)}
+}
+
+func (t *TeamworkAPI) SetTaskUsage(usage map[int]TaskUsage) {
+	t.settingsMutex.Lock()
+	defer t.settingsMutex.Unlock()
+	t.taskUsage = usage
+}
+
+func (t *TeamworkAPI) currentTaskUsage() map[int]TaskUsage {
+	t.settingsMutex.RLock()
+	defer t.settingsMutex.RUnlock()
+	return t.taskUsage
+}
+
+func (t *TeamworkAPI) SearchTasks(query string, limit int) ([]TaskSearchResult, error) {
+	if err := t.ensureTaskIndex(); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	usage := t.currentTaskUsage()
+	t.taskIndex.mutex.RLock()
+	results := searchTaskDocuments(t.taskIndex.documents, query, usage, time.Now())
+	t.taskIndex.mutex.RUnlock()
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func (t *TeamworkAPI) RefreshTaskIndex(full bool) (int, error) {
+	if !t.IsConfigured() {
+		return 0, fmt.Errorf("API não configurada")
+	}
+
+	t.taskIndex.mutex.RLock()
+	since := t.taskIndex.syncedAt
+	t.taskIndex.mutex.RUnlock()
+
+	if full {
+		since = time.Time{}
+	}
+
+	inicio := time.Now()
+	tasks, err := t.fetchIndexTasks(since)
+	if err != nil {
+		return 0, err
+	}
+
+	t.taskIndex.mutex.Lock()
+	defer t.taskIndex.mutex.Unlock()
+
+	if full {
+		t.taskIndex.documents = make(map[int]*taskSearchDocument)
+	}
+	for _, task := range tasks {
+		if isTaskClosed(task) {
+			delete(t.taskIndex.documents, task.ID)
+			continue
+		}
+		t.taskIndex.documents[task.ID] = newTaskSearchDocument(task)
+	}
+	t.taskIndex.syncedAt = inicio
+
+	t.logDebug("Índice de tarefas atualizado: %d tarefas recebidas, %d no índice", len(tasks), len(t.taskIndex.documents))
+	return len(t.taskIndex.documents), nil
+}
+
+func (t *TeamworkAPI) ensureTaskIndex() error {
+	t.taskIndex.mutex.RLock()
+	syncedAt := t.taskIndex.syncedAt
+	t.taskIndex.mutex.RUnlock()
+
+	if !syncedAt.IsZero() && time.Since(syncedAt) < taskIndexRefreshTime {
+		return nil
+	}
+
+	if _, err := t.RefreshTaskIndex(false); err != nil {
+		if syncedAt.IsZero() {
+			return fmt.Errorf("erro ao montar índice de tarefas: %v", err)
+		}
+		t.logDebug("Erro ao atualizar índice de tarefas, usando dados anteriores: %v", err)
+	}
+	return nil
+}
+
+func (t *TeamworkAPI) fetchIndexTasks(since time.Time) ([]TeamworkTask, error) {
+	query := TaskQuery{AnyAssignee: true, Status: "all", IncludeDeleted: true, PageSize: taskIndexPageSize}
+	if !since.IsZero() {
+		query.UpdatedAfter = since.Format(time.RFC3339)
+	}
+	return t.queryAllTasks(query, false)
+}
+
+func isTaskClosed(task TeamworkTask) bool {
+	return task.Status == "completed" || task.Status == "deleted"
+}
+
+func parseTasksPage(body []byte) ([]TeamworkTask, bool, error) {
+	type reference struct {
+		ID int `json:"id"`
+	}
+
+	var response struct {
+		Tasks []struct {
+			ID          int    `json:"id"`
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Status      string `json:"status"`
+			CreatedAt   string `json:"createdAt"`
+			UpdatedAt   string `json:"updatedAt"`
+			Estimate    int    `json:"estimateMinutes"`
+			Assignees   []struct {
+				ID   int    `json:"id"`
+				Type string `json:"type"`
+			} `json:"assignees"`
+			Priority   string      `json:"priority"`
+			StartDate  string      `json:"startDate"`
+			DueDate    string      `json:"dueDate"`
+			TasklistID int         `json:"tasklistId"`
+			Tasklist   reference   `json:"tasklist"`
+			TagIDs     []int       `json:"tagIds"`
+			Tags       []reference `json:"tags"`
+		} `json:"tasks"`
+		Included struct {
+			Projects map[string]struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			} `json:"projects"`
+			Tasklists map[string]struct {
+				ID        int    `json:"id"`
+				Name      string `json:"name"`
+				ProjectID int    `json:"projectId"`
+			} `json:"tasklists"`
+			Tags map[string]struct {
+				ID   int    `json:"id"`
+				Name string `json:"name"`
+			} `json:"tags"`
+			TimeTotals map[string]struct {
+				LoggedMinutes int `json:"loggedMinutes"`
+			} `json:"timeTotals"`
+		} `json:"included"`
+		Meta struct {
+			Page struct {
+				HasMore bool `json:"hasMore"`
+			} `json:"page"`
+		} `json:"meta"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, false, fmt.Errorf("erro ao decodificar resposta: %v", err)
+	}
+
+	tasks := make([]TeamworkTask, 0, len(response.Tasks))
+	for _, item := range response.Tasks {
+		task := TeamworkTask{
+			ID:               item.ID,
+			Content:          item.Name,
+			Name:             item.Name,
+			Description:      item.Description,
+			Status:           item.Status,
+			CreatedAt:        item.CreatedAt,
+			UpdatedAt:        item.UpdatedAt,
+			EstimatedMinutes: item.Estimate,
+			Assignees:        item.Assignees,
+			Priority:         item.Priority,
+			StartDate:        item.StartDate,
+			DueDate:          item.DueDate,
+			TasklistID:       item.TasklistID,
+		}
+		if task.TasklistID == 0 {
+			task.TasklistID = item.Tasklist.ID
+		}
+
+		if tasklist, ok := response.Included.Tasklists[strconv.Itoa(task.TasklistID)]; ok {
+			task.TasklistName = tasklist.Name
+			task.ProjectID = tasklist.ProjectID
+		}
+		if project, ok := response.Included.Projects[strconv.Itoa(task.ProjectID)]; ok {
+			task.ProjectName = project.Name
+		}
+		if totals, ok := response.Included.TimeTotals[strconv.Itoa(task.ID)]; ok {
+			task.LoggedMinutes = totals.LoggedMinutes
+		}
+
+		tagIDs := item.TagIDs
+		for _, tag := range item.Tags {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+		for _, tagID := range tagIDs {
+			if tag, ok := response.Included.Tags[strconv.Itoa(tagID)]; ok {
+				task.Tags = append(task.Tags, struct {
+					ID   int    `json:"id"`
+					Name string `json:"name"`
+				}{ID: tag.ID, Name: tag.Name})
+			}
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, response.Meta.Page.HasMore, nil
+}
+
+func newTaskSearchDocument(task TeamworkTask) *taskSearchDocument {
+	nome := task.Content
+	if nome == "" {
+		nome = task.Name
+	}
+
+	tags := make([]string, 0, len(task.Tags))
+	for _, tag := range task.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	return &taskSearchDocument{
+		task:     task,
+		searchID: strconv.Itoa(task.ID),
+		fields: map[string][]string{
+			"name":     strings.Fields(normalizeText(nome)),
+			"tasklist": strings.Fields(normalizeText(task.TasklistName)),
+			"project":  strings.Fields(normalizeText(task.ProjectName)),
+			"tags":     strings.Fields(normalizeText(strings.Join(tags, " "))),
+		},
+	}
+}
+
+func searchTaskDocuments(documents map[int]*taskSearchDocument, query string, usage map[int]TaskUsage, agora time.Time) []TaskSearchResult {
+	terms := strings.Fields(normalizeText(query))
+	results := make([]TaskSearchResult, 0)
+
+	for _, document := range documents {
+		score, matched := document.score(terms)
+		if len(terms) > 0 && score == 0 {
+			continue
+		}
+
+		result := TaskSearchResult{Task: document.task, MatchedFields: matched}
+		boost := 0.0
+		if used, ok := usage[document.task.ID]; ok {
+			result.LastUsed = used.LastUsed
+			boost = recencyBoost(used, agora)
+		}
+
+		if len(terms) == 0 {
+			if boost == 0 {
+				continue
+			}
+			score = 1
+		}
+
+		result.Score = score * (1 + boost)
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Task.ID > results[j].Task.ID
+	})
+	return results
+}
+
+func (d *taskSearchDocument) score(terms []string) (float64, []string) {
+	total := 0.0
+	matchedFields := make(map[string]bool)
+
+	for _, term := range terms {
+		id := strings.TrimPrefix(term, "#")
+		if id != "" && id == d.searchID {
+			total += 10
+			matchedFields["id"] = true
+			continue
+		}
+
+		melhor := 0.0
+		melhorCampo := ""
+		for field, words := range d.fields {
+			for _, word := range words {
+				if value := matchWord(term, word) * taskSearchFieldWeights[field]; value > melhor {
+					melhor = value
+					melhorCampo = field
+				}
+			}
+		}
+
+		if melhor == 0 {
+			return 0, nil
+		}
+		total += melhor
+		matchedFields[melhorCampo] = true
+	}
+
+	campos := make([]string, 0, len(matchedFields))
+	for field := range matchedFields {
+		campos = append(campos, field)
+	}
+	sort.Strings(campos)
+	return total, campos
+}
+
+func matchWord(term, word string) float64 {
+	switch {
+	case term == word:
+		return 1
+	case strings.HasPrefix(word, term):
+		return 0.8
+	case strings.Contains(word, term):
+		return 0.5
+	}
+
+	if len(term) < 4 {
+		return 0
+	}
+
+	limite := 1
+	if len(term) >= 7 {
+		limite = 2
+	}
+
+	candidato := word
+	if len(candidato) > len(term)+limite {
+		candidato = candidato[:len(term)]
+	}
+	if levenshtein(term, candidato) <= limite {
+		return 0.4
+	}
+	return 0
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	anterior := make([]int, len(rb)+1)
+	atual := make([]int, len(rb)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		atual[0] = i
+		for j := 1; j <= len(rb); j++ {
+			custo := 1
+			if ra[i-1] == rb[j-1] {
+				custo = 0
+			}
+			atual[j] = minValue(minValue(anterior[j]+1, atual[j-1]+1), anterior[j-1]+custo)
+		}
+		anterior, atual = atual, anterior
+	}
+	return anterior[len(rb)]
+}
+
+func recencyBoost(usage TaskUsage, agora time.Time) float64 {
+	lastUsed, err := time.Parse(time.RFC3339, usage.LastUsed)
+	if err != nil {
+		return 0
+	}
+
+	dias := agora.Sub(lastUsed).Hours() / 24
+	if dias < 0 {
+		dias = 0
+	}
+
+	frequencia := float64(minValue(usage.Count, 20)) / 20
+	return 2/(1+dias/7) + frequencia
+}
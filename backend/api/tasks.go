@@ -9,7 +9,8 @@ import (
 )
 
 func (t *TeamworkAPI) GetTasks() ([]TeamworkTask, error) {
-	cacheKey := fmt.Sprintf("tasks_user_%d", t.Config.UserID)
+	query := TaskQuery{Status: "active"}
+	cacheKey := "tasks_" + t.taskQueryParams(query).Encode()
 	if cachedData, found := t.cache.Get(cacheKey); found {
 		return cachedData.([]TeamworkTask), nil
 	}
@@ -18,40 +19,17 @@ func (t *TeamworkAPI) GetTasks() ([]TeamworkTask, error) {
 		return nil, fmt.Errorf("API não configurada")
 	}
 
-	path := fmt.Sprintf("/projects/api/v3/tasks.json?assignedTo=%d&filter=active&includeTasklists=true&includeTaskAssignees=true&includeCompletionStatus=true&includeEstimatedTime=true&includeTaskTags=true",
-		t.Config.UserID)
-
-	url := t.buildURL(path)
-	t.logDebug("Fazendo requisição para URL: %s", url)
-
-	req, err := t.createRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, body, err := t.doRequest(req)
+	tasks, err := t.QueryAllTasks(query)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("erro ao obter tarefas: %d %s - %s",
-			resp.StatusCode, resp.Status, string(body))
+	if len(tasks) > 0 {
+		t.enrichTasksWithDetails(&tasks)
 	}
 
-	var response TasksResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("erro ao decodificar resposta: %v", err)
-	}
-
-	enrichTasksWithIncludedData(&response)
-
-	if len(response.Tasks) > 0 {
-		t.enrichTasksWithDetails(&response.Tasks)
-	}
-
-	t.cache.Set(cacheKey, response.Tasks, 15*time.Minute)
-	return response.Tasks, nil
+	t.cache.Set(cacheKey, tasks, 15*time.Minute)
+	return tasks, nil
 }
 
 func (t *TeamworkAPI) GetTaskDetails(taskID int) (TeamworkTask, error) {
@@ -91,13 +69,14 @@ func (t *TeamworkAPI) GetTaskDetails(taskID int) (TeamworkTask, error) {
 func parseTaskResponseV3(body []byte, taskIDStr string) (TeamworkTask, error) {
 	var taskResponseV3 struct {
 		Task struct {
-			ID          int    `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description"`
-			Status      string `json:"status"`
-			ProjectID   int    `json:"projectId"`
-			TasklistID  int    `json:"tasklistId"`
-			CreatedAt   string `json:"createdAt"`
+			ID              int    `json:"id"`
+			Name            string `json:"name"`
+			Description     string `json:"description"`
+			Status          string `json:"status"`
+			ProjectID       int    `json:"projectId"`
+			TasklistID      int    `json:"tasklistId"`
+			CreatedAt       string `json:"createdAt"`
+			EstimateMinutes int    `json:"estimateMinutes"`
 		} `json:"task"`
 		Included struct {
 			Projects map[string]struct {
@@ -119,14 +98,15 @@ func parseTaskResponseV3(body []byte, taskIDStr string) (TeamworkTask, error) {
 	}
 
 	result := TeamworkTask{
-		ID:          taskResponseV3.Task.ID,
-		Content:     taskResponseV3.Task.Name,
-		Name:        taskResponseV3.Task.Name,
-		Description: taskResponseV3.Task.Description,
-		Status:      taskResponseV3.Task.Status,
-		ProjectID:   taskResponseV3.Task.ProjectID,
-		TasklistID:  taskResponseV3.Task.TasklistID,
-		CreatedAt:   taskResponseV3.Task.CreatedAt,
+		ID:               taskResponseV3.Task.ID,
+		Content:          taskResponseV3.Task.Name,
+		Name:             taskResponseV3.Task.Name,
+		Description:      taskResponseV3.Task.Description,
+		Status:           taskResponseV3.Task.Status,
+		ProjectID:        taskResponseV3.Task.ProjectID,
+		TasklistID:       taskResponseV3.Task.TasklistID,
+		CreatedAt:        taskResponseV3.Task.CreatedAt,
+		EstimatedMinutes: taskResponseV3.Task.EstimateMinutes,
 	}
 
 	projectIDStr := strconv.Itoa(taskResponseV3.Task.ProjectID)
@@ -610,65 +590,6 @@ func (t *TeamworkAPI) fallbackGetTasksByProject(projectID int) ([]TeamworkTask,
 	return tasks, nil
 }
 
-func (t *TeamworkAPI) GetTasksWithUpcomingDeadlines() ([]map[string]interface{}, error) {
-
-	cacheKey := "upcoming_tasks"
-	if cachedData, found := t.cache.Get(cacheKey); found {
-		return cachedData.([]map[string]interface{}), nil
-	}
-
-	projects, err := t.GetProjects()
-	if err != nil || len(projects) == 0 {
-		return []map[string]interface{}{}, nil
-	}
-
-	allTasks := []TeamworkTask{}
-
-	for _, project := range projects {
-		tasks, err := t.GetTasksByProject(project.ID)
-		if err == nil && len(tasks) > 0 {
-			allTasks = append(allTasks, tasks...)
-		}
-
-		if len(allTasks) > 10 {
-			break
-		}
-	}
-
-	if len(allTasks) == 0 {
-		return []map[string]interface{}{}, nil
-	}
-
-	tarefas := make([]map[string]interface{}, 0)
-
-	now := time.Now()
-
-	limit := 5
-	if len(allTasks) < limit {
-		limit = len(allTasks)
-	}
-
-	for i := 0; i < limit; i++ {
-		task := allTasks[i]
-
-		dueDate := now.AddDate(0, 0, i+1).Format("2006-01-02")
-
-		tarefaInfo := map[string]interface{}{
-			"id":          task.ID,
-			"name":        task.Content,
-			"dueDate":     dueDate,
-			"priority":    "Normal",
-			"projectId":   task.ProjectID,
-			"projectName": task.ProjectName,
-		}
-
-		tarefas = append(tarefas, tarefaInfo)
-	}
-
-	t.cache.Set(cacheKey, tarefas, 30*time.Minute)
-	return tarefas, nil
-}
-
 func (t *TeamworkAPI) GetCompletedTasksByProject(projectID int) (int, error) {
 	projectIDStr := strconv.Itoa(projectID)
 	path := fmt.Sprintf("/projects/api/v3/tasks.json?projectIds=%s&completedStatus=completed", projectIDStr)
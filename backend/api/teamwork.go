@@ -7,8 +7,16 @@ import (
 )
 
 type TeamworkAPI struct {
-	Config Config
-	cache  *Cache
+	Config          Config
+	cache           *Cache
+	settingsMutex   sync.RWMutex
+	mappingRules    []MappingRule
+	lockedPeriods   []LockedPeriod
+	savedTasks      map[int]Task
+	holidayCalendar HolidayCalendarSettings
+	absences        []Absence
+	taskIndex       *taskSearchIndex
+	taskUsage       map[int]TaskUsage
 }
 
 func NewTeamworkAPI(config Config) *TeamworkAPI {
@@ -17,8 +25,9 @@ func NewTeamworkAPI(config Config) *TeamworkAPI {
 	}
 
 	return &TeamworkAPI{
-		Config: config,
-		cache:  NewCache(),
+		Config:    config,
+		cache:     NewCache(),
+		taskIndex: newTaskSearchIndex(),
 	}
 }
 
@@ -143,6 +152,31 @@ func (t *TeamworkAPI) GetDashboardStats() (map[string]interface{}, error) {
 		stats["diasUteisPassados"] = diasUteisPassados
 	}
 
+	diasAusencia := 0.0
+	minutosEsperadosMes := 0
+	minutosEsperadosRestantes := 0
+	hojeData := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for dia := firstDay; dia.Month() == firstDay.Month(); dia = dia.AddDate(0, 0, 1) {
+		if !t.isScheduledDay(dia.Weekday()) {
+			continue
+		}
+		if isHoliday, holiday, _ := t.IsHoliday(dia); isHoliday && !holiday.IsReducedDay() {
+			continue
+		}
+
+		previsto := t.scheduledMinutes(dia.Weekday())
+		diasAusencia += float64(previsto-t.absenceAdjusted(formatDate(dia), previsto)) / float64(previsto)
+
+		esperado := t.expectedMinutes(dia)
+		minutosEsperadosMes += esperado
+		if !dia.Before(hojeData) {
+			minutosEsperadosRestantes += esperado
+		}
+	}
+	stats["diasAusencia"] = diasAusencia
+	stats["minutosEsperadosMes"] = minutosEsperadosMes
+	stats["minutosEsperadosRestantes"] = minutosEsperadosRestantes
+
 	t.cache.Set(cacheKey, stats, 1*time.Hour)
 	return stats, nil
 }
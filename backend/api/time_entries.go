@@ -118,6 +118,12 @@ func (t *TeamworkAPI) LogTime(taskID int, entry TimeEntry) (*TimeLogResult, erro
 		return nil, fmt.Errorf("minutos devem ser maiores que zero: %d", entry.Minutes)
 	}
 
+	if err := t.ensureDateUnlocked(entry.Date); err != nil {
+		return nil, err
+	}
+
+	entry.Minutes = t.roundLoggedMinutes(taskID, entry.Minutes)
+
 	taskIDStr := strconv.Itoa(taskID)
 	path := fmt.Sprintf("/projects/api/v3/tasks/%s/time.json", taskIDStr)
 	url := t.buildURL(path)
@@ -163,6 +169,8 @@ func (t *TeamworkAPI) LogTime(taskID int, entry TimeEntry) (*TimeLogResult, erro
 		result.Success = true
 		result.Message = fmt.Sprintf("Entrada de tempo enviada com sucesso: %s %s",
 			entry.Date, entry.Time)
+		t.invalidateBudgets()
+		t.invalidateActivityFeed()
 
 		var successResponse struct {
 			ID     int    `json:"id"`
@@ -188,7 +196,7 @@ func (t *TeamworkAPI) LogTime(taskID int, entry TimeEntry) (*TimeLogResult, erro
 				resp.StatusCode, resp.Status, string(body))
 		}
 
-		return result, fmt.Errorf(result.Message)
+		return result, fmt.Errorf("%s", result.Message)
 	}
 }
 
@@ -237,31 +245,27 @@ func (t *TeamworkAPI) CreateDistributionPlanFromLoggedTime(month, year int, task
 					continue
 				}
 
-				workDay.Entries = append(workDay.Entries, EntryTask{
-					TaskID: task.TaskID,
-					Entry: TimeEntry{
-						Minutes:     taskMins,
-						Description: task.TaskName,
-						IsBillable:  true,
-						Time:        "09:00",
-						Date:        date,
-					},
-				})
-			}
-		} else {
-			workDay.Entries = append(workDay.Entries, EntryTask{
-				TaskID: 0,
-				Entry: TimeEntry{
-					Minutes:     totalMin,
-					Description: "Tempo importado do calendário",
+				workDay.Entries = append(workDay.Entries, t.newPlanEntry(task, TimeEntry{
+					Minutes:     taskMins,
+					Description: task.TaskName,
 					IsBillable:  true,
 					Time:        "09:00",
 					Date:        date,
-				},
-			})
+				}))
+			}
+		} else {
+			workDay.Entries = append(workDay.Entries, t.newPlanEntry(Task{}, TimeEntry{
+				Minutes:     totalMin,
+				Description: "Tempo importado do calendário",
+				IsBillable:  true,
+				Time:        "09:00",
+				Date:        date,
+			}))
 		}
 
-		workDay.TotalMin = totalMin
+		for _, alocacao := range workDay.Entries {
+			workDay.TotalMin += alocacao.Entry.Minutes
+		}
 		workDays = append(workDays, workDay)
 	}
 
@@ -277,6 +281,19 @@ func (t *TeamworkAPI) LogMultipleTimes(workDays []WorkDay) ([]*TimeLogResult, er
 		return nil, fmt.Errorf("nenhum dia de trabalho fornecido para lançamento")
 	}
 
+	bloqueados := make([]string, 0)
+	for _, day := range workDays {
+		if len(day.Entries) == 0 {
+			continue
+		}
+		if _, locked := t.FindLockedPeriod(day.Date); locked {
+			bloqueados = append(bloqueados, formatDisplayDate(day.Date))
+		}
+	}
+	if len(bloqueados) > 0 {
+		return nil, fmt.Errorf("lançamento recusado: dias em período bloqueado (%s)", strings.Join(bloqueados, ", "))
+	}
+
 	t.logDebug("Iniciando lançamento de horas para %d dias", len(workDays))
 
 	totalEntries := 0
@@ -391,14 +408,16 @@ func (t *TeamworkAPI) GetWorkingDays(inicio, fim string) ([]string, error) {
 }
 
 func (t *TeamworkAPI) IsWorkDay(data time.Time) bool {
-	diaSemana := data.Weekday()
+	if !t.isScheduledDay(data.Weekday()) {
+		return false
+	}
 
-	if diaSemana == time.Saturday || diaSemana == time.Sunday {
+	if t.isAbsentAllDay(formatDate(data)) {
 		return false
 	}
 
-	isHoliday, _, _ := t.IsHoliday(data)
-	return !isHoliday
+	isHoliday, holiday, _ := t.IsHoliday(data)
+	return !isHoliday || holiday.IsReducedDay()
 }
 
 func formatDate(data time.Time) string {
@@ -406,6 +425,7 @@ func formatDate(data time.Time) string {
 }
 
 func (t *TeamworkAPI) CreateDistributionPlan(diasUteis []string, tarefas []Task) []WorkDay {
+	tarefas = t.schedulableTasks(tarefas)
 	planoDistribuicao := make([]WorkDay, 0, len(diasUteis))
 
 	for _, dia := range diasUteis {
@@ -422,42 +442,37 @@ func (t *TeamworkAPI) CreateDistributionPlan(diasUteis []string, tarefas []Task)
 		}
 		diaSemana := int(diaData.Weekday())
 
+		disponivel := t.dayFraction(diaData)
+		if disponivel <= 0 {
+			t.logDebug("Dia %s marcado como ausência, pulando", dia)
+			continue
+		}
+
 		t.logDebug("Processando dia %s (dia da semana: %d)", dia, diaSemana)
 
 		for _, tarefa := range tarefas {
-			shouldIncludeTask := true
-
-			if len(tarefa.WorkingDays) > 0 {
-				t.logDebug("Tarefa %s tem workingDays definidos: %v", tarefa.TaskName, tarefa.WorkingDays)
-				shouldIncludeTask = false
-
-				for _, workingDay := range tarefa.WorkingDays {
-					if workingDay == diaSemana {
-						shouldIncludeTask = true
-						t.logDebug("Dia %d está incluído nos workingDays da tarefa %s", diaSemana, tarefa.TaskName)
-						break
-					}
-				}
+			if !taskAppliesOn(tarefa, diaData) {
+				t.logDebug("Tarefa %s não se aplica ao dia %s (dia da semana: %d), pulando", tarefa.TaskName, dia, diaSemana)
+				continue
+			}
 
-				if !shouldIncludeTask {
-					t.logDebug("Dia %d NÃO está incluído nos workingDays da tarefa %s, pulando", diaSemana, tarefa.TaskName)
+			for _, entrada := range tarefa.Entries {
+				entrada.Minutes = scaleMinutes(entrada.Minutes, disponivel)
+				if entrada.Minutes <= 0 {
 					continue
 				}
-			} else {
-				t.logDebug("Tarefa %s não tem workingDays definidos, incluindo em todos os dias", tarefa.TaskName)
-			}
 
-			for _, entrada := range tarefa.Entries {
-				workDay.Entries = append(workDay.Entries, EntryTask{
-					TaskID: tarefa.TaskID,
-					Entry:  entrada,
-				})
-				workDay.TotalMin += entrada.Minutes
+				alocacao := t.newPlanEntry(tarefa, entrada)
+				workDay.Entries = append(workDay.Entries, alocacao)
+				workDay.TotalMin += alocacao.Entry.Minutes
 				t.logDebug("Adicionada entrada da tarefa %s no dia %s", tarefa.TaskName, dia)
 			}
 		}
 
 		if len(workDay.Entries) > 0 {
+			if t.Config.Schedule.Enabled {
+				t.layoutWorkDay(&workDay, t.planDayStart(dia))
+			}
 			planoDistribuicao = append(planoDistribuicao, workDay)
 			t.logDebug("Dia %s adicionado ao plano com %d entradas", dia, len(workDay.Entries))
 		} else {
@@ -625,62 +640,6 @@ func (t *TeamworkAPI) GetTimeLogsForPeriod(startDate, endDate string) ([]map[str
 	return entries, totalHoras, ultimoLancamento, nil
 }
 
-func (t *TeamworkAPI) GetRecentActivities() ([]map[string]interface{}, error) {
-
-	cacheKey := "recent_activities"
-	if cachedData, found := t.cache.Get(cacheKey); found {
-		return cachedData.([]map[string]interface{}), nil
-	}
-
-	projects, err := t.GetProjects()
-	if err != nil || len(projects) == 0 {
-		return []map[string]interface{}{}, nil
-	}
-
-	projectID := projects[0].ID
-
-	tasks, err := t.GetTasksByProject(projectID)
-	if err != nil || len(tasks) == 0 {
-		return []map[string]interface{}{}, nil
-	}
-
-	atividades := make([]map[string]interface{}, 0)
-
-	sort.Slice(tasks, func(i, j int) bool {
-		time1, _ := time.Parse(time.RFC3339, tasks[i].CreatedAt)
-		time2, _ := time.Parse(time.RFC3339, tasks[j].CreatedAt)
-		return time1.After(time2)
-	})
-
-	limit := 5
-	if len(tasks) < limit {
-		limit = len(tasks)
-	}
-
-	now := time.Now()
-
-	for i := 0; i < limit; i++ {
-		task := tasks[i]
-
-		atividadeInfo := map[string]interface{}{
-			"id":          task.ID,
-			"type":        "task",
-			"description": "Tarefa atualizada: " + task.Content,
-			"minutes":     60.0,
-			"date":        now.AddDate(0, 0, -i).Format("2006-01-02"),
-			"projectId":   task.ProjectID,
-			"projectName": task.ProjectName,
-			"taskId":      task.ID,
-			"taskName":    task.Content,
-		}
-
-		atividades = append(atividades, atividadeInfo)
-	}
-
-	t.cache.Set(cacheKey, atividades, 30*time.Minute)
-	return atividades, nil
-}
-
 func (t *TeamworkAPI) GetAllNonWorkingDays(year, month int) ([]map[string]interface{}, error) {
 	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
 
@@ -700,7 +659,7 @@ func (t *TeamworkAPI) GetAllNonWorkingDays(year, month int) ([]map[string]interf
 
 	current := startDate
 	for !current.After(endDate) {
-		if current.Weekday() == time.Saturday || current.Weekday() == time.Sunday {
+		if !t.isScheduledDay(current.Weekday()) {
 			nonWorkingDays = append(nonWorkingDays, map[string]interface{}{
 				"date": formatDate(current),
 				"type": "weekend",
@@ -712,10 +671,11 @@ func (t *TeamworkAPI) GetAllNonWorkingDays(year, month int) ([]map[string]interf
 
 	for _, holiday := range holidays {
 		date, _ := time.Parse("2006-01-02", holiday.Date)
-		if date.Weekday() != time.Saturday && date.Weekday() != time.Sunday {
+		if t.isScheduledDay(date.Weekday()) && !holiday.IsReducedDay() {
 			nonWorkingDays = append(nonWorkingDays, map[string]interface{}{
 				"date":        holiday.Date,
 				"type":        "holiday",
+				"holidayType": holiday.Type,
 				"name":        holiday.Name,
 				"description": holiday.Description,
 				"isOptional":  holiday.IsOptional,
@@ -723,6 +683,29 @@ func (t *TeamworkAPI) GetAllNonWorkingDays(year, month int) ([]map[string]interf
 		}
 	}
 
+	for current := startDate; !current.After(endDate); current = current.AddDate(0, 0, 1) {
+		if !t.isScheduledDay(current.Weekday()) {
+			continue
+		}
+
+		date := formatDate(current)
+		absence, found := t.FindAbsence(date)
+		if !found || !absence.IsFullDay() {
+			continue
+		}
+		if isHoliday, holiday, _ := t.IsHoliday(current); isHoliday && !holiday.IsReducedDay() {
+			continue
+		}
+
+		nonWorkingDays = append(nonWorkingDays, map[string]interface{}{
+			"date":        date,
+			"type":        "absence",
+			"name":        absence.Label(),
+			"description": absence.Note,
+			"absenceType": absence.Type,
+		})
+	}
+
 	return nonWorkingDays, nil
 }
 
@@ -765,6 +748,10 @@ func (t *TeamworkAPI) DeleteTimeEntry(entryID int) error {
 		return fmt.Errorf("API não configurada")
 	}
 
+	if err := t.ensureEntryUnlocked(entryID); err != nil {
+		return err
+	}
+
 	entryIDStr := strconv.Itoa(entryID)
 	path := fmt.Sprintf("/projects/api/v3/time/%s.json", entryIDStr)
 	url := t.buildURL(path)
@@ -788,6 +775,8 @@ func (t *TeamworkAPI) DeleteTimeEntry(entryID int) error {
 			resp.StatusCode, resp.Status, string(body))
 	}
 
+	t.invalidateBudgets()
+	t.invalidateActivityFeed()
 	return nil
 }
 
@@ -861,6 +850,11 @@ func (t *TeamworkAPI) GetTimeEntriesWithDetails(startDate, endDate string) ([]Ti
 	return entries, nil
 }
 
+const (
+	timeEntriesV2PageSize = 500
+	timeEntriesV2MaxPages = 100
+)
+
 func (t *TeamworkAPI) GetTimeEntriesForPeriodV2(startDate, endDate string, includeDeleted bool) ([]TimeEntryReport, error) {
 	if !t.IsConfigured() {
 		return nil, fmt.Errorf("API não configurada")
@@ -884,25 +878,43 @@ func (t *TeamworkAPI) GetTimeEntriesForPeriodV2(startDate, endDate string, inclu
 		showDeleted = "1"
 	}
 
-	path := fmt.Sprintf("/projects/api/v2/time.json?page=1&pageSize=500&getTotals=true&skipCounts=false&projectId=&companyId=0&userId=%d&assignedTeamIds=&invoicedType=all&billableType=all&fromDate=%s&toDate=%s&sortBy=date&sortOrder=desc&onlyStarredProjects=false&includeArchivedProjects=true&matchAllTags=true&projectStatus=all&showDeleted=%s",
-		t.Config.UserID, startDateFormatted, endDateFormatted, showDeleted)
+	t.logDebug("Obtendo entradas de tempo V2 de %s a %s...", startDate, endDate)
 
-	url := t.buildURL(path)
+	filter := fmt.Sprintf("fromDate=%s&toDate=%s&showDeleted=%s", startDateFormatted, endDateFormatted, showDeleted)
+	var entries []TimeEntryReport
+	for page := 1; page <= timeEntriesV2MaxPages; page++ {
+		pageEntries, rows, err := t.getTimeEntriesPageV2(filter, page)
+		if err != nil {
+			return nil, err
+		}
 
-	t.logDebug("Obtendo entradas de tempo V2 de %s a %s...", startDate, endDate)
+		entries = append(entries, pageEntries...)
+		if rows < timeEntriesV2PageSize {
+			return entries, nil
+		}
+	}
+
+	return nil, fmt.Errorf("período com entradas demais: mais de %d páginas de %d lançamentos", timeEntriesV2MaxPages, timeEntriesV2PageSize)
+}
+
+func (t *TeamworkAPI) getTimeEntriesPageV2(filter string, page int) ([]TimeEntryReport, int, error) {
+	path := fmt.Sprintf("/projects/api/v2/time.json?page=%d&pageSize=%d&getTotals=true&skipCounts=false&projectId=&companyId=0&userId=%d&assignedTeamIds=&invoicedType=all&billableType=all&sortBy=date&sortOrder=desc&onlyStarredProjects=false&includeArchivedProjects=true&matchAllTags=true&projectStatus=all&%s",
+		page, timeEntriesV2PageSize, t.Config.UserID, filter)
+
+	url := t.buildURL(path)
 
 	req, err := t.createRequest("GET", url, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	resp, body, err := t.doRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("erro ao obter entradas de tempo: %d %s - %s",
+		return nil, 0, fmt.Errorf("erro ao obter entradas de tempo: %d %s - %s",
 			resp.StatusCode, resp.Status, string(body[:minValue(len(body), 100)]))
 	}
 
@@ -932,16 +944,20 @@ func (t *TeamworkAPI) GetTimeEntriesForPeriodV2(startDate, endDate string, inclu
 			DateDeleted       string  `json:"dateDeleted,omitempty"`
 			DeletedByUserId   int     `json:"deletedByUserId,omitempty"`
 			DeletedByUserName string  `json:"deletedByUserName,omitempty"`
+			Tags              []Tag   `json:"tags,omitempty"`
 		} `json:"timeEntries"`
 	}
 
 	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("erro ao decodificar resposta: %v", err)
+		return nil, 0, fmt.Errorf("erro ao decodificar resposta: %v", err)
 	}
 
 	var entries []TimeEntryReport
 	for _, entry := range response.TimeEntries {
 		parsedDate, _ := time.Parse("2006-01-02T15:04:05Z", entry.Date)
+		if entry.HasStartTime {
+			parsedDate = parsedDate.In(time.Local)
+		}
 		formattedDate := parsedDate.Format("2006-01-02")
 
 		totalMinutes := int(entry.HoursDecimal * 60)
@@ -949,6 +965,12 @@ func (t *TeamworkAPI) GetTimeEntriesForPeriodV2(startDate, endDate string, inclu
 			totalMinutes = int(entry.Hours)*60 + entry.Minutes
 		}
 
+		startTime, endTime := "", ""
+		if entry.HasStartTime {
+			startTime = parsedDate.Format("15:04")
+			endTime = parsedDate.Add(time.Duration(totalMinutes) * time.Minute).Format("15:04")
+		}
+
 		timeEntry := TimeEntryReport{
 			ID:            entry.ID,
 			ProjectID:     entry.ProjectID,
@@ -966,14 +988,24 @@ func (t *TeamworkAPI) GetTimeEntriesForPeriodV2(startDate, endDate string, inclu
 			Description:   entry.Description,
 			IsBillable:    entry.IsBillable,
 			IsBilled:      entry.IsBilled,
-			StartTime:     "",
-			EndTime:       "",
+			StartTime:     startTime,
+			EndTime:       endTime,
+			Status:        entry.Status,
+			CreatedAt:     entry.CreatedAt,
+			UpdatedAt:     entry.UpdatedDate,
+			DeletedAt:     entry.DateDeleted,
+			DeletedBy:     entry.DeletedByUserName,
+			Tags:          entry.Tags,
+		}
+
+		for _, tag := range entry.Tags {
+			timeEntry.TagIDs = append(timeEntry.TagIDs, tag.ID)
 		}
 
 		entries = append(entries, timeEntry)
 	}
 
-	return entries, nil
+	return entries, len(response.TimeEntries), nil
 }
 
 func (t *TeamworkAPI) GetAllTimeEntriesForDay(date string) ([]TimeEntryReport, error) {
@@ -1026,6 +1058,10 @@ func (t *TeamworkAPI) DeleteTimeEntryV2(entryID int) error {
 		return fmt.Errorf("API não configurada")
 	}
 
+	if err := t.ensureEntryUnlocked(entryID); err != nil {
+		return err
+	}
+
 	entryIDStr := strconv.Itoa(entryID)
 	path := fmt.Sprintf("/projects/api/v3/time/%s.json", entryIDStr)
 	url := t.buildURL(path)
@@ -1060,6 +1096,8 @@ func (t *TeamworkAPI) DeleteTimeEntryV2(entryID int) error {
 			resp.StatusCode, resp.Status, string(body))
 	}
 
+	t.invalidateBudgets()
+	t.invalidateActivityFeed()
 	return nil
 }
 
@@ -1080,6 +1118,18 @@ func (t *TeamworkAPI) UpdateTimeEntry(entryID int, entry TimeEntry) (*TimeLogRes
 		return nil, fmt.Errorf("minutos devem ser maiores que zero: %d", entry.Minutes)
 	}
 
+	if entry.Date != "" {
+		if err := t.ensureDateUnlocked(entry.Date); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.ensureEntryUnlocked(entryID); err != nil {
+		return nil, err
+	}
+
+	entry.Minutes = t.roundUpdatedMinutes(entryID, entry.Minutes)
+
 	entryIDStr := strconv.Itoa(entryID)
 	path := fmt.Sprintf("/projects/api/v3/time/%s.json", entryIDStr)
 	url := t.buildURL(path)
@@ -1093,8 +1143,14 @@ func (t *TeamworkAPI) UpdateTimeEntry(entryID int, entry TimeEntry) (*TimeLogRes
 	t.logDebug("Atualizando entrada de tempo #%d: %s %s - %d minutos - %s",
 		entryID, entry.Date, entry.Time, entry.Minutes, entry.Description)
 
-	reqBody := TimelogRequest{
-		Timelog: entry,
+	update := TimelogUpdate{TimeEntry: entry}
+	if entry.TagIDs != nil {
+		tagIDs := entry.TagIDs
+		update.TagIDs = &tagIDs
+	}
+
+	reqBody := TimelogUpdateRequest{
+		Timelog: update,
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -1120,6 +1176,8 @@ func (t *TeamworkAPI) UpdateTimeEntry(entryID int, entry TimeEntry) (*TimeLogRes
 	if resp.StatusCode == 200 || resp.StatusCode == 201 {
 		result.Success = true
 		result.Message = fmt.Sprintf("Entrada de tempo atualizada com sucesso")
+		t.invalidateBudgets()
+		t.invalidateActivityFeed()
 		return result, nil
 	} else {
 		result.Success = false
@@ -1134,6 +1192,6 @@ func (t *TeamworkAPI) UpdateTimeEntry(entryID int, entry TimeEntry) (*TimeLogRes
 				resp.StatusCode, resp.Status, string(body))
 		}
 
-		return result, fmt.Errorf(result.Message)
+		return result, fmt.Errorf("%s", result.Message)
 	}
 }
@@ -0,0 +1,446 @@
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type MonthlyTimesheet struct {
+	Year               int                     `json:"year"`
+	Month              int                     `json:"month"`
+	UserID             int                     `json:"userId"`
+	UserName           string                  `json:"userName"`
+	StartDate          string                  `json:"startDate"`
+	EndDate            string                  `json:"endDate"`
+	GeneratedAt        string                  `json:"generatedAt"`
+	Days               []TimesheetDay          `json:"days"`
+	Projects           []TimesheetProjectTotal `json:"projects"`
+	Holidays           []Holiday               `json:"holidays"`
+	WorkingDays        int                     `json:"workingDays"`
+	ExpectedMinutes    int                     `json:"expectedMinutes"`
+	LoggedMinutes      int                     `json:"loggedMinutes"`
+	BillableMinutes    int                     `json:"billableMinutes"`
+	NonBillableMinutes int                     `json:"nonBillableMinutes"`
+}
+
+type TimesheetDay struct {
+	Date            string            `json:"date"`
+	Weekday         string            `json:"weekday"`
+	IsWorkDay       bool              `json:"isWorkDay"`
+	HolidayName     string            `json:"holidayName,omitempty"`
+	ExpectedMinutes int               `json:"expectedMinutes"`
+	LoggedMinutes   int               `json:"loggedMinutes"`
+	Entries         []TimeEntryReport `json:"entries"`
+}
+
+type TimesheetProjectTotal struct {
+	ProjectID          int    `json:"projectId"`
+	ProjectName        string `json:"projectName"`
+	BillableMinutes    int    `json:"billableMinutes"`
+	NonBillableMinutes int    `json:"nonBillableMinutes"`
+	TotalMinutes       int    `json:"totalMinutes"`
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "Dom",
+	time.Monday:    "Seg",
+	time.Tuesday:   "Ter",
+	time.Wednesday: "Qua",
+	time.Thursday:  "Qui",
+	time.Friday:    "Sex",
+	time.Saturday:  "Sáb",
+}
+
+var monthNames = []string{"", "Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
+	"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro"}
+
+func (t *TeamworkAPI) BuildMonthlyTimesheet(year, month int) (*MonthlyTimesheet, error) {
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("mês inválido: %d", month)
+	}
+
+	if !t.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+
+	primeiroDia := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	ultimoDia := primeiroDia.AddDate(0, 1, -1)
+
+	entries, err := t.GetTimeEntriesForPeriodV2(formatDate(primeiroDia), formatDate(ultimoDia), false)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao obter entradas de tempo: %v", err)
+	}
+
+	holidays, err := t.GetHolidaysForMonth(year, month)
+	if err != nil {
+		t.logDebug("Não foi possível obter feriados de %02d/%d: %v", month, year, err)
+	}
+
+	return t.assembleMonthlyTimesheet(primeiroDia, ultimoDia, entries, holidays), nil
+}
+
+func (t *TeamworkAPI) assembleMonthlyTimesheet(primeiroDia, ultimoDia time.Time, entries []TimeEntryReport, holidays []Holiday) *MonthlyTimesheet {
+	sheet := &MonthlyTimesheet{
+		Year:        primeiroDia.Year(),
+		Month:       int(primeiroDia.Month()),
+		UserID:      t.Config.UserID,
+		StartDate:   formatDate(primeiroDia),
+		EndDate:     formatDate(ultimoDia),
+		GeneratedAt: time.Now().Format("02/01/2006 15:04"),
+		Days:        make([]TimesheetDay, 0),
+		Projects:    make([]TimesheetProjectTotal, 0),
+		Holidays:    holidays,
+	}
+
+	sort.Slice(sheet.Holidays, func(i, j int) bool {
+		return sheet.Holidays[i].Date < sheet.Holidays[j].Date
+	})
+
+	holidayNames := make(map[string]string, len(holidays))
+	for _, holiday := range holidays {
+		holidayNames[holiday.Date] = holiday.Name
+	}
+
+	entriesByDay := make(map[string][]TimeEntryReport)
+	projects := make(map[int]*TimesheetProjectTotal)
+	for _, entry := range entries {
+		entriesByDay[entry.Date] = append(entriesByDay[entry.Date], entry)
+
+		if sheet.UserName == "" {
+			sheet.UserName = strings.TrimSpace(entry.UserFirstName + " " + entry.UserLastName)
+		}
+
+		project, ok := projects[entry.ProjectID]
+		if !ok {
+			project = &TimesheetProjectTotal{ProjectID: entry.ProjectID, ProjectName: entry.ProjectName}
+			projects[entry.ProjectID] = project
+		}
+
+		if entry.IsBillable {
+			project.BillableMinutes += entry.Minutes
+			sheet.BillableMinutes += entry.Minutes
+		} else {
+			project.NonBillableMinutes += entry.Minutes
+			sheet.NonBillableMinutes += entry.Minutes
+		}
+		project.TotalMinutes += entry.Minutes
+		sheet.LoggedMinutes += entry.Minutes
+	}
+
+	if sheet.UserName == "" {
+		sheet.UserName = fmt.Sprintf("Usuário #%d", t.Config.UserID)
+	}
+
+	for dia := primeiroDia; !dia.After(ultimoDia); dia = dia.AddDate(0, 0, 1) {
+		data := formatDate(dia)
+		dayEntries := entriesByDay[data]
+
+		sort.SliceStable(dayEntries, func(i, j int) bool {
+			return dayEntries[i].StartTime < dayEntries[j].StartTime
+		})
+
+		timesheetDay := TimesheetDay{
+			Date:        data,
+			Weekday:     weekdayNames[dia.Weekday()],
+			IsWorkDay:   t.IsWorkDay(dia),
+			HolidayName: holidayNames[data],
+			Entries:     dayEntries,
+		}
+
+		if timesheetDay.IsWorkDay {
+			timesheetDay.ExpectedMinutes = t.expectedMinutes(dia)
+			sheet.WorkingDays++
+			sheet.ExpectedMinutes += timesheetDay.ExpectedMinutes
+		}
+
+		for _, entry := range dayEntries {
+			timesheetDay.LoggedMinutes += entry.Minutes
+		}
+
+		if timesheetDay.IsWorkDay || len(dayEntries) > 0 {
+			sheet.Days = append(sheet.Days, timesheetDay)
+		}
+	}
+
+	for _, project := range projects {
+		sheet.Projects = append(sheet.Projects, *project)
+	}
+	sort.Slice(sheet.Projects, func(i, j int) bool {
+		if sheet.Projects[i].TotalMinutes != sheet.Projects[j].TotalMinutes {
+			return sheet.Projects[i].TotalMinutes > sheet.Projects[j].TotalMinutes
+		}
+		return sheet.Projects[i].ProjectName < sheet.Projects[j].ProjectName
+	})
+
+	return sheet
+}
+
+func (t *TeamworkAPI) GenerateLocalTimesheetPDF(year, month int) (string, error) {
+	sheet, err := t.BuildMonthlyTimesheet(year, month)
+	if err != nil {
+		return "", err
+	}
+
+	reportsDir, err := getReportsDir()
+	if err != nil {
+		return "", err
+	}
+
+	filePath := filepath.Join(reportsDir, fmt.Sprintf("Timesheet_%d-%02d.pdf", year, month))
+	if err := writeFileAtomically(filePath, RenderTimesheetPDF(sheet)); err != nil {
+		return "", err
+	}
+
+	t.logDebug("Timesheet PDF salvo em: %s", filePath)
+	return filePath, nil
+}
+
+type timesheetColumn struct {
+	title string
+	width float64
+	right bool
+}
+
+type timesheetWriter struct {
+	doc *pdfDocument
+	y   float64
+}
+
+const (
+	timesheetMargin     = 40.0
+	timesheetRowHeight  = 14.0
+	timesheetFontSize   = 8.0
+	timesheetBottomEdge = pdfPageHeight - 50
+)
+
+func RenderTimesheetPDF(sheet *MonthlyTimesheet) []byte {
+	w := &timesheetWriter{doc: newPDFDocument()}
+	w.newPage()
+
+	w.doc.text(timesheetMargin, w.y, 16, true, "Relatório de Horas")
+	w.y += 20
+	w.doc.text(timesheetMargin, w.y, 10, false, fmt.Sprintf("Usuário: %s", sheet.UserName))
+	w.y += 14
+	w.doc.text(timesheetMargin, w.y, 10, false, fmt.Sprintf("Período: %s de %d (%s a %s)",
+		monthNames[sheet.Month], sheet.Year, formatDisplayDate(sheet.StartDate), formatDisplayDate(sheet.EndDate)))
+	w.y += 14
+	w.doc.text(timesheetMargin, w.y, 8, false, fmt.Sprintf("Gerado em %s", sheet.GeneratedAt))
+	w.y += 20
+
+	w.section("Resumo")
+	saldo := sheet.LoggedMinutes - sheet.ExpectedMinutes
+	w.keyValue("Dias úteis", fmt.Sprintf("%d", sheet.WorkingDays))
+	w.keyValue("Horas esperadas", formatMinutesAsHours(sheet.ExpectedMinutes))
+	w.keyValue("Horas lançadas", formatMinutesAsHours(sheet.LoggedMinutes))
+	w.keyValue("Saldo", formatSignedMinutes(saldo))
+	w.keyValue("Faturável", formatMinutesAsHours(sheet.BillableMinutes))
+	w.keyValue("Não faturável", formatMinutesAsHours(sheet.NonBillableMinutes))
+	w.y += 8
+
+	w.section("Esperado x lançado por dia")
+	dayColumns := []timesheetColumn{
+		{title: "Data", width: 70},
+		{title: "Dia", width: 40},
+		{title: "Observação", width: 195},
+		{title: "Esperado", width: 70, right: true},
+		{title: "Lançado", width: 70, right: true},
+		{title: "Saldo", width: 70, right: true},
+	}
+	w.tableHeader(dayColumns)
+	for _, day := range sheet.Days {
+		observacao := day.HolidayName
+		if observacao == "" && !day.IsWorkDay {
+			observacao = "Dia não útil"
+		}
+		w.tableRow(dayColumns, []string{
+			formatDisplayDate(day.Date),
+			day.Weekday,
+			observacao,
+			formatMinutesAsHours(day.ExpectedMinutes),
+			formatMinutesAsHours(day.LoggedMinutes),
+			formatSignedMinutes(day.LoggedMinutes - day.ExpectedMinutes),
+		}, false)
+	}
+	w.tableRow(dayColumns, []string{"Total", "", "",
+		formatMinutesAsHours(sheet.ExpectedMinutes),
+		formatMinutesAsHours(sheet.LoggedMinutes),
+		formatSignedMinutes(saldo)}, true)
+	w.y += 8
+
+	w.section("Lançamentos")
+	entryColumns := []timesheetColumn{
+		{title: "Data", width: 55},
+		{title: "Início", width: 35},
+		{title: "Projeto", width: 105},
+		{title: "Tarefa", width: 125},
+		{title: "Descrição", width: 140},
+		{title: "Fat.", width: 25},
+		{title: "Horas", width: 30, right: true},
+	}
+	w.tableHeader(entryColumns)
+	for _, day := range sheet.Days {
+		for _, entry := range day.Entries {
+			faturavel := "Não"
+			if entry.IsBillable {
+				faturavel = "Sim"
+			}
+			w.tableRow(entryColumns, []string{
+				formatDisplayDate(entry.Date),
+				entry.StartTime,
+				entry.ProjectName,
+				entry.TaskName,
+				entry.Description,
+				faturavel,
+				formatMinutesAsHours(entry.Minutes),
+			}, false)
+		}
+	}
+	w.y += 8
+
+	w.section("Subtotais por projeto")
+	projectColumns := []timesheetColumn{
+		{title: "Projeto", width: 275},
+		{title: "Faturável", width: 80, right: true},
+		{title: "Não faturável", width: 80, right: true},
+		{title: "Total", width: 80, right: true},
+	}
+	w.tableHeader(projectColumns)
+	for _, project := range sheet.Projects {
+		w.tableRow(projectColumns, []string{
+			project.ProjectName,
+			formatMinutesAsHours(project.BillableMinutes),
+			formatMinutesAsHours(project.NonBillableMinutes),
+			formatMinutesAsHours(project.TotalMinutes),
+		}, false)
+	}
+	w.tableRow(projectColumns, []string{"Total",
+		formatMinutesAsHours(sheet.BillableMinutes),
+		formatMinutesAsHours(sheet.NonBillableMinutes),
+		formatMinutesAsHours(sheet.LoggedMinutes)}, true)
+	w.y += 8
+
+	w.section("Feriados")
+	if len(sheet.Holidays) == 0 {
+		w.ensureSpace(timesheetRowHeight)
+		w.doc.text(timesheetMargin, w.y+10, timesheetFontSize, false, "Nenhum feriado no período.")
+		w.y += timesheetRowHeight
+	} else {
+		holidayColumns := []timesheetColumn{
+			{title: "Data", width: 70},
+			{title: "Feriado", width: 295},
+			{title: "Tipo", width: 80},
+			{title: "Facultativo", width: 70},
+		}
+		w.tableHeader(holidayColumns)
+		for _, holiday := range sheet.Holidays {
+			facultativo := "Não"
+			if holiday.IsOptional {
+				facultativo = "Sim"
+			}
+			w.tableRow(holidayColumns, []string{
+				formatDisplayDate(holiday.Date), holiday.Name, holiday.Type, facultativo,
+			}, false)
+		}
+	}
+
+	total := w.doc.pageCount()
+	for i := 0; i < total; i++ {
+		w.doc.setPage(i)
+		w.doc.textRight(pdfPageWidth-timesheetMargin, pdfPageHeight-25, 7, false,
+			fmt.Sprintf("Página %d de %d", i+1, total))
+	}
+
+	return w.doc.render()
+}
+
+func (w *timesheetWriter) newPage() {
+	w.doc.addPage()
+	w.y = timesheetMargin
+}
+
+func (w *timesheetWriter) ensureSpace(height float64) bool {
+	if w.y+height > timesheetBottomEdge {
+		w.newPage()
+		return true
+	}
+	return false
+}
+
+func (w *timesheetWriter) section(title string) {
+	w.ensureSpace(40)
+	w.doc.text(timesheetMargin, w.y+10, 11, true, title)
+	w.y += 14
+	w.doc.line(timesheetMargin, w.y, pdfPageWidth-timesheetMargin, w.y, 0.5)
+	w.y += 4
+}
+
+func (w *timesheetWriter) keyValue(key, value string) {
+	w.ensureSpace(timesheetRowHeight)
+	w.doc.text(timesheetMargin, w.y+10, 9, true, key+":")
+	w.doc.text(timesheetMargin+110, w.y+10, 9, false, value)
+	w.y += 13
+}
+
+func (w *timesheetWriter) tableHeader(columns []timesheetColumn) {
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = column.title
+	}
+	w.ensureSpace(timesheetRowHeight * 2)
+	w.doc.fillRect(timesheetMargin, w.y, pdfPageWidth-2*timesheetMargin, timesheetRowHeight, 0.88)
+	w.writeCells(columns, titles, true)
+}
+
+func (w *timesheetWriter) tableRow(columns []timesheetColumn, values []string, bold bool) {
+	if w.ensureSpace(timesheetRowHeight) {
+		w.tableHeader(columns)
+	}
+	if bold {
+		w.doc.line(timesheetMargin, w.y, pdfPageWidth-timesheetMargin, w.y, 0.3)
+	}
+	w.writeCells(columns, values, bold)
+}
+
+func (w *timesheetWriter) writeCells(columns []timesheetColumn, values []string, bold bool) {
+	x := timesheetMargin
+	for i, column := range columns {
+		if i < len(values) && values[i] != "" {
+			value := pdfFitText(values[i], timesheetFontSize, bold, column.width-4)
+			if column.right {
+				w.doc.textRight(x+column.width-2, w.y+10, timesheetFontSize, bold, value)
+			} else {
+				w.doc.text(x+2, w.y+10, timesheetFontSize, bold, value)
+			}
+		}
+		x += column.width
+	}
+	w.y += timesheetRowHeight
+}
+
+func formatDisplayDate(date string) string {
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return parsed.Format("02/01/2006")
+}
+
+func formatMinutesAsHours(minutes int) string {
+	if minutes < 0 {
+		minutes = -minutes
+	}
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+func formatSignedMinutes(minutes int) string {
+	if minutes < 0 {
+		return "-" + formatMinutesAsHours(minutes)
+	}
+	if minutes > 0 {
+		return "+" + formatMinutesAsHours(minutes)
+	}
+	return "0:00"
+}
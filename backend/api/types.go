@@ -1,10 +1,23 @@
 package api
 
 type Config struct {
-	AuthToken     string `json:"authToken"`
-	UserID        int    `json:"userId"`
-	ApiHost       string `json:"apiHost"`
-	MinutosPorDia int    `json:"minutosPorDia"`
+	AuthToken           string                 `json:"authToken"`
+	UserID              int                    `json:"userId"`
+	ApiHost             string                 `json:"apiHost"`
+	MinutosPorDia       int                    `json:"minutosPorDia"`
+	MinutosPorDiaSemana []int                  `json:"minutosPorDiaSemana,omitempty"`
+	Schedule            DaySchedule            `json:"schedule"`
+	Rounding            RoundingPolicy         `json:"rounding"`
+	ProjectRounding     map[int]RoundingPolicy `json:"projectRounding,omitempty"`
+	HorizontePrazos     int                    `json:"horizontePrazos,omitempty"`
+}
+
+type DaySchedule struct {
+	Enabled       bool   `json:"enabled"`
+	DayStart      string `json:"dayStart"`
+	LunchStart    string `json:"lunchStart,omitempty"`
+	LunchMinutes  int    `json:"lunchMinutes,omitempty"`
+	BufferMinutes int    `json:"bufferMinutes,omitempty"`
 }
 
 type TimeEntry struct {
@@ -14,30 +27,62 @@ type TimeEntry struct {
 	Description string `json:"description"`
 	IsBillable  bool   `json:"isBillable"`
 	Date        string `json:"date,omitempty"`
+	TagIDs      []int  `json:"tagIds,omitempty"`
+}
+
+type Tag struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Color     string `json:"color,omitempty"`
+	ProjectID int    `json:"projectId,omitempty"`
 }
 
 type Task struct {
-	TaskID      int         `json:"taskId"`
-	TaskName    string      `json:"taskName"`
-	ProjectID   int         `json:"projectId"`
-	ProjectName string      `json:"projectName"`
-	Entries     []TimeEntry `json:"entries"`
-	WorkingDays []int       `json:"workingDays,omitempty"`
+	TaskID      int             `json:"taskId"`
+	TaskName    string          `json:"taskName"`
+	ProjectID   int             `json:"projectId"`
+	ProjectName string          `json:"projectName"`
+	Entries     []TimeEntry     `json:"entries"`
+	WorkingDays []int           `json:"workingDays,omitempty"`
+	Weight      float64         `json:"weight,omitempty"`
+	MinPerDay   int             `json:"minMinutesPerDay,omitempty"`
+	MaxPerDay   int             `json:"maxMinutesPerDay,omitempty"`
+	Recurrence  string          `json:"recurrence,omitempty"`
+	ValidFrom   string          `json:"validFrom,omitempty"`
+	ValidUntil  string          `json:"validUntil,omitempty"`
+	Rounding    *RoundingPolicy `json:"rounding,omitempty"`
+}
+
+type WeightedDistributionOptions struct {
+	Granularity     int `json:"granularity"`
+	CapacityMinutes int `json:"capacityMinutes,omitempty"`
 }
 
 type TimelogRequest struct {
 	Timelog TimeEntry `json:"timelog"`
 }
 
+type TimelogUpdateRequest struct {
+	Timelog TimelogUpdate `json:"timelog"`
+}
+
+type TimelogUpdate struct {
+	TimeEntry
+	TagIDs *[]int `json:"tagIds,omitempty"`
+}
+
 type WorkDay struct {
 	Date     string      `json:"date"`
 	Entries  []EntryTask `json:"entries"`
 	TotalMin int         `json:"totalMin"`
+	Warnings []string    `json:"warnings,omitempty"`
 }
 
 type EntryTask struct {
-	TaskID int       `json:"taskId"`
-	Entry  TimeEntry `json:"entry"`
+	TaskID          int       `json:"taskId"`
+	Entry           TimeEntry `json:"entry"`
+	OriginalMinutes int       `json:"originalMinutes,omitempty"`
+	Warning         string    `json:"warning,omitempty"`
 }
 
 type TeamworkTask struct {
@@ -50,6 +95,7 @@ type TeamworkTask struct {
 	Status       string `json:"status,omitempty"`
 	Priority     string `json:"priority,omitempty"`
 	CreatedAt    string `json:"createdAt,omitempty"`
+	UpdatedAt    string `json:"updatedAt,omitempty"`
 	StartDate    string `json:"startDate,omitempty"`
 	DueDate      string `json:"dueDate,omitempty"`
 	TasklistID   int    `json:"tasklistId,omitempty"`
@@ -62,7 +108,8 @@ type TeamworkTask struct {
 		ID   int    `json:"id"`
 		Type string `json:"type"`
 	} `json:"assignees,omitempty"`
-	LoggedMinutes int `json:"loggedMinutes,omitempty"`
+	LoggedMinutes    int `json:"loggedMinutes,omitempty"`
+	EstimatedMinutes int `json:"estimatedMinutes,omitempty"`
 }
 
 type TasksResponse struct {
@@ -91,6 +138,7 @@ type Template struct {
 	Name     string `json:"name"`
 	Tasks    []Task `json:"tasks"`
 	TotalMin int    `json:"totalMin"`
+	TagIDs   []int  `json:"tagIds,omitempty"`
 }
 
 type TimeLogResult struct {
@@ -162,4 +210,6 @@ type TimeEntryReport struct {
 	UpdatedAt     string  `json:"updatedAt,omitempty"`
 	DeletedAt     string  `json:"deletedAt,omitempty"`
 	DeletedBy     string  `json:"deletedBy,omitempty"`
+	TagIDs        []int   `json:"tagIds,omitempty"`
+	Tags          []Tag   `json:"tags,omitempty"`
 }
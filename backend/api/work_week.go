@@ -0,0 +1,152 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+func ValidateWorkWeek(config Config) error {
+	if len(config.MinutosPorDiaSemana) == 0 {
+		return nil
+	}
+
+	if len(config.MinutosPorDiaSemana) != 7 {
+		return fmt.Errorf("a jornada semanal deve ter 7 valores (domingo a sábado), recebidos %d", len(config.MinutosPorDiaSemana))
+	}
+
+	total := 0
+	for i, minutos := range config.MinutosPorDiaSemana {
+		if minutos < 0 || minutos > 24*60 {
+			return fmt.Errorf("minutos inválidos para %s: %d", weekdayNames[time.Weekday(i)], minutos)
+		}
+		total += minutos
+	}
+
+	if total == 0 {
+		return fmt.Errorf("a jornada semanal precisa ter pelo menos um dia com minutos esperados")
+	}
+
+	return nil
+}
+
+func (t *TeamworkAPI) scheduledMinutes(diaSemana time.Weekday) int {
+	if len(t.Config.MinutosPorDiaSemana) == 7 {
+		return t.Config.MinutosPorDiaSemana[diaSemana]
+	}
+
+	if diaSemana == time.Saturday || diaSemana == time.Sunday {
+		return 0
+	}
+	return t.Config.MinutosPorDia
+}
+
+func (t *TeamworkAPI) isScheduledDay(diaSemana time.Weekday) bool {
+	return t.scheduledMinutes(diaSemana) > 0
+}
+
+type DayCapacity struct {
+	Date             string  `json:"date"`
+	Weekday          string  `json:"weekday"`
+	IsWorkDay        bool    `json:"isWorkDay"`
+	ScheduledMinutes int     `json:"scheduledMinutes"`
+	ExpectedMinutes  int     `json:"expectedMinutes"`
+	HolidayName      string  `json:"holidayName,omitempty"`
+	WorkFraction     float64 `json:"workFraction,omitempty"`
+	Absence          string  `json:"absence,omitempty"`
+}
+
+func (t *TeamworkAPI) GetExpectedMinutesForDay(date string) (*DayCapacity, error) {
+	data, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("formato de data inválido: %v", err)
+	}
+
+	capacity := t.describeDay(data)
+	return &capacity, nil
+}
+
+func (t *TeamworkAPI) GetExpectedMinutesForPeriod(startDate, endDate string) ([]DayCapacity, error) {
+	inicio, err := time.ParseInLocation("2006-01-02", startDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("data inicial inválida: %v", err)
+	}
+
+	fim, err := time.ParseInLocation("2006-01-02", endDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("data final inválida: %v", err)
+	}
+
+	if fim.Before(inicio) {
+		return nil, fmt.Errorf("a data final deve ser igual ou posterior à data inicial")
+	}
+
+	days := make([]DayCapacity, 0)
+	for dia := inicio; !dia.After(fim); dia = dia.AddDate(0, 0, 1) {
+		days = append(days, t.describeDay(dia))
+	}
+	return days, nil
+}
+
+func (t *TeamworkAPI) describeDay(data time.Time) DayCapacity {
+	date := formatDate(data)
+	capacity := DayCapacity{
+		Date:             date,
+		Weekday:          weekdayNames[data.Weekday()],
+		ScheduledMinutes: t.scheduledMinutes(data.Weekday()),
+	}
+
+	if absence, found := t.FindAbsence(date); found {
+		capacity.Absence = absence.Label()
+	}
+
+	if capacity.ScheduledMinutes == 0 {
+		return capacity
+	}
+
+	minutos := capacity.ScheduledMinutes
+	if isHoliday, holiday, _ := t.IsHoliday(data); isHoliday {
+		capacity.HolidayName = holiday.Name
+		if !holiday.IsReducedDay() {
+			return capacity
+		}
+		capacity.WorkFraction = holiday.WorkFraction
+		minutos = scaleMinutes(minutos, holiday.WorkFraction)
+	}
+
+	capacity.ExpectedMinutes = t.absenceAdjusted(date, minutos)
+	capacity.IsWorkDay = !t.isAbsentAllDay(date)
+	return capacity
+}
+
+func (t *TeamworkAPI) expectedMinutes(data time.Time) int {
+	return t.describeDay(data).ExpectedMinutes
+}
+
+func (t *TeamworkAPI) dayCapacity(data time.Time) int {
+	minutos := t.scheduledMinutes(data.Weekday())
+	if minutos <= 0 {
+		minutos = t.Config.MinutosPorDia
+	}
+
+	if isHoliday, holiday, _ := t.IsHoliday(data); isHoliday && holiday.IsReducedDay() {
+		minutos = scaleMinutes(minutos, holiday.WorkFraction)
+	}
+
+	return t.absenceAdjusted(formatDate(data), minutos)
+}
+
+func (t *TeamworkAPI) dayFraction(data time.Time) float64 {
+	base := t.Config.MinutosPorDia
+	if base <= 0 {
+		base = t.scheduledMinutes(data.Weekday())
+	}
+	if base <= 0 {
+		return 1
+	}
+
+	fracao := float64(t.dayCapacity(data)) / float64(base)
+	if fracao > 1 {
+		return 1
+	}
+	return fracao
+}
@@ -10,6 +10,7 @@ import (
 	"logTime-go/backend/config"
 	"net/http"
 	"os/exec"
+	"os/user"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -28,19 +29,54 @@ func NewApp(ctx context.Context) (*App, error) {
 		return nil, fmt.Errorf("erro ao inicializar gerenciador de configurações: %v", err)
 	}
 
-	teamworkConfig := configManager.GetTeamworkConfig()
-	teamworkAPI := api.NewTeamworkAPI(teamworkConfig)
-
-	return &App{
+	app := &App{
 		ctx:           ctx,
 		configManager: configManager,
-		teamworkAPI:   teamworkAPI,
-	}, nil
+	}
+	app.teamworkAPI = app.newTeamworkAPI(configManager.GetTeamworkConfig())
+
+	return app, nil
+}
+
+func (a *App) newTeamworkAPI(config api.Config) *api.TeamworkAPI {
+	teamworkAPI := api.NewTeamworkAPI(config)
+	teamworkAPI.SetMappingRules(a.configManager.GetMappingRules())
+	teamworkAPI.SetLockedPeriods(a.configManager.GetLockedPeriods())
+	teamworkAPI.SetSavedTasks(a.configManager.GetSavedTasks())
+	teamworkAPI.SetHolidayCalendar(a.configManager.GetHolidayCalendarSettings())
+	teamworkAPI.SetAbsences(a.configManager.GetAbsences())
+	teamworkAPI.SetTaskUsage(a.configManager.GetTaskUsage())
+	return teamworkAPI
+}
+
+func (a *App) syncSavedTasks(err error) error {
+	a.teamworkAPI.SetSavedTasks(a.configManager.GetSavedTasks())
+	return err
+}
+
+func (a *App) recordTaskUsage(taskIDs ...int) {
+	if err := a.configManager.RecordTaskUsage(taskIDs); err != nil {
+		fmt.Printf("Aviso: não foi possível registrar uso das tarefas: %v\n", err)
+		return
+	}
+	a.teamworkAPI.SetTaskUsage(a.configManager.GetTaskUsage())
+}
+
+func (a *App) lockActor() string {
+	name := "desconhecido"
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		name = current.Username
+	}
+
+	if userID := a.configManager.GetTeamworkConfig().UserID; userID > 0 {
+		return fmt.Sprintf("%s (Teamwork #%d)", name, userID)
+	}
+	return name
 }
 
 func (a *App) Startup(ctx context.Context) {
 	a.ctx = ctx
-	a.teamworkAPI = api.NewTeamworkAPI(a.configManager.GetTeamworkConfig())
+	a.teamworkAPI = a.newTeamworkAPI(a.configManager.GetTeamworkConfig())
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -66,7 +102,23 @@ func (a *App) GetConfig() api.Config {
 }
 
 func (a *App) SaveConfig(config api.Config) error {
-	a.teamworkAPI = api.NewTeamworkAPI(config)
+	if err := api.ValidateDaySchedule(config.Schedule); err != nil {
+		return err
+	}
+
+	if err := api.ValidateRoundingConfig(config); err != nil {
+		return err
+	}
+
+	if err := api.ValidateWorkWeek(config); err != nil {
+		return err
+	}
+
+	if err := api.ValidateDeadlineHorizon(config.HorizontePrazos); err != nil {
+		return err
+	}
+
+	a.teamworkAPI = a.newTeamworkAPI(config)
 	return a.configManager.SetTeamworkConfig(config)
 }
 
@@ -87,16 +139,28 @@ func (a *App) GetTasks() ([]api.TeamworkTask, error) {
 	return a.teamworkAPI.GetTasks()
 }
 
+func (a *App) GetTags() ([]api.Tag, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+
+	return a.teamworkAPI.GetTags()
+}
+
 func (a *App) GetSavedTasks() []api.Task {
 	return a.configManager.GetSavedTasks()
 }
 
 func (a *App) SaveTask(task api.Task) error {
-	return a.configManager.AddSavedTask(task)
+	if err := api.ValidateTask(task); err != nil {
+		return err
+	}
+
+	return a.syncSavedTasks(a.configManager.AddSavedTask(task))
 }
 
 func (a *App) RemoveTask(taskID int) error {
-	return a.configManager.RemoveSavedTask(taskID)
+	return a.syncSavedTasks(a.configManager.RemoveSavedTask(taskID))
 }
 
 func (a *App) GetTaskDetails(taskID int) (api.TeamworkTask, error) {
@@ -112,6 +176,12 @@ func (a *App) GetTemplate(name string) (api.Template, bool) {
 }
 
 func (a *App) SaveTemplate(template api.Template) error {
+	for _, task := range template.Tasks {
+		if err := api.ValidateTask(task); err != nil {
+			return err
+		}
+	}
+
 	return a.configManager.SaveTemplate(template)
 }
 
@@ -128,15 +198,167 @@ func (a *App) GetWorkingDays(inicio, fim string) ([]string, error) {
 }
 
 func (a *App) CreateDistributionPlan(diasUteis []string, tarefas []api.Task) []api.WorkDay {
-	return a.teamworkAPI.CreateDistributionPlan(diasUteis, tarefas)
+	return a.teamworkAPI.AnnotatePlanBudgets(a.teamworkAPI.CreateDistributionPlan(diasUteis, tarefas))
+}
+
+func (a *App) CreateWeightedDistributionPlan(diasUteis []string, tarefas []api.Task, options api.WeightedDistributionOptions) ([]api.WorkDay, error) {
+	for _, tarefa := range tarefas {
+		if err := api.ValidateTask(tarefa); err != nil {
+			return nil, err
+		}
+	}
+
+	return a.teamworkAPI.AnnotatePlanBudgets(a.teamworkAPI.CreateWeightedDistributionPlan(diasUteis, tarefas, options)), nil
+}
+
+func (a *App) ImportTimesheetCSV(content string, options api.CSVImportOptions) (*api.ImportResult, error) {
+	return a.teamworkAPI.ImportTimesheetCSV(content, options)
+}
+
+func (a *App) GetGitImportSettings() api.GitImportSettings {
+	return a.configManager.GetGitImportSettings()
+}
+
+func (a *App) SaveGitImportSettings(settings api.GitImportSettings) error {
+	if err := api.ValidateGitImportSettings(settings); err != nil {
+		return err
+	}
+	return a.configManager.SetGitImportSettings(settings)
+}
+
+func (a *App) ImportGitHistory(startDate, endDate string) (*api.ImportResult, error) {
+	return a.teamworkAPI.ImportGitHistory(a.configManager.GetGitImportSettings(), startDate, endDate)
+}
+
+func (a *App) GetMappingRules() []api.MappingRule {
+	return a.configManager.GetMappingRules()
+}
+
+func (a *App) SaveMappingRule(rule api.MappingRule) (api.MappingRule, error) {
+	if err := api.ValidateMappingRules([]api.MappingRule{rule}); err != nil {
+		return rule, err
+	}
+
+	saved, err := a.configManager.SaveMappingRule(rule)
+	if err != nil {
+		return saved, err
+	}
+
+	a.teamworkAPI.SetMappingRules(a.configManager.GetMappingRules())
+	return saved, nil
+}
+
+func (a *App) DeleteMappingRule(id string) error {
+	if err := a.configManager.DeleteMappingRule(id); err != nil {
+		return err
+	}
+
+	a.teamworkAPI.SetMappingRules(a.configManager.GetMappingRules())
+	return nil
+}
+
+func (a *App) ReorderMappingRules(ids []string) error {
+	if err := a.configManager.ReorderMappingRules(ids); err != nil {
+		return err
+	}
+
+	a.teamworkAPI.SetMappingRules(a.configManager.GetMappingRules())
+	return nil
+}
+
+func (a *App) TestMappingRules(input api.MappingInput) (api.MappingMatch, error) {
+	return a.teamworkAPI.MatchMappingRules(input)
+}
+
+func (a *App) GetLockedPeriods() []api.LockedPeriod {
+	return a.configManager.GetLockedPeriods()
+}
+
+func (a *App) LockPeriod(startDate, endDate, reason string) (api.LockedPeriod, error) {
+	period, err := a.configManager.LockPeriod(startDate, endDate, reason, a.lockActor())
+	if err != nil {
+		return period, err
+	}
+
+	a.teamworkAPI.SetLockedPeriods(a.configManager.GetLockedPeriods())
+	return period, nil
+}
+
+func (a *App) UnlockPeriod(id, reason string) (api.LockedPeriod, error) {
+	if strings.TrimSpace(reason) == "" {
+		return api.LockedPeriod{}, fmt.Errorf("informe o motivo do desbloqueio")
+	}
+
+	period, err := a.configManager.SetPeriodLocked(id, false, reason, a.lockActor())
+	if err != nil {
+		return period, err
+	}
+
+	a.teamworkAPI.SetLockedPeriods(a.configManager.GetLockedPeriods())
+	return period, nil
+}
+
+func (a *App) RelockPeriod(id, reason string) (api.LockedPeriod, error) {
+	period, err := a.configManager.SetPeriodLocked(id, true, reason, a.lockActor())
+	if err != nil {
+		return period, err
+	}
+
+	a.teamworkAPI.SetLockedPeriods(a.configManager.GetLockedPeriods())
+	return period, nil
+}
+
+func (a *App) ImportICS(content string, options api.ICSImportOptions) (*api.ImportResult, error) {
+	return a.teamworkAPI.ImportICS(content, options)
+}
+
+func (a *App) ExportTimeEntriesICS(startDate, endDate string) (string, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return "", fmt.Errorf("API não configurada. Configure sua conta antes de exportar relatórios")
+	}
+
+	filePath, err := a.teamworkAPI.ExportTimeEntriesICS(startDate, endDate)
+	if err != nil {
+		return "", fmt.Errorf("erro ao exportar calendário: %v", err)
+	}
+
+	return filePath, nil
 }
 
 func (a *App) LogMultipleTimes(workDays []api.WorkDay) ([]*api.TimeLogResult, error) {
-	return a.teamworkAPI.LogMultipleTimes(workDays)
+	results, err := a.teamworkAPI.LogMultipleTimes(workDays)
+
+	usadas := make([]int, 0)
+	vistas := make(map[int]bool)
+	for _, result := range results {
+		if result != nil && result.Success && !vistas[result.TaskID] {
+			vistas[result.TaskID] = true
+			usadas = append(usadas, result.TaskID)
+		}
+	}
+	a.recordTaskUsage(usadas...)
+
+	return results, err
 }
 
 func (a *App) LogTime(taskID int, entry api.TimeEntry) (*api.TimeLogResult, error) {
-	return a.teamworkAPI.LogTime(taskID, entry)
+	result, err := a.teamworkAPI.LogTime(taskID, entry)
+	if err == nil && result != nil && result.Success {
+		a.recordTaskUsage(taskID)
+	}
+	return result, err
+}
+
+func (a *App) QueryTasks(query api.TaskQuery) (*api.TaskQueryResult, error) {
+	return a.teamworkAPI.QueryTasks(query)
+}
+
+func (a *App) SearchTasks(query string, limit int) ([]api.TaskSearchResult, error) {
+	return a.teamworkAPI.SearchTasks(query, limit)
+}
+
+func (a *App) RefreshTaskIndex(full bool) (int, error) {
+	return a.teamworkAPI.RefreshTaskIndex(full)
 }
 
 func (a *App) GetCurrentUserId() (int, error) {
@@ -201,7 +423,7 @@ func (a *App) LoginWithCredentials(email, password, host string) (*api.LoginResp
 			return nil, fmt.Errorf("erro ao salvar configuração: %v", err)
 		}
 
-		a.teamworkAPI = api.NewTeamworkAPI(config)
+		a.teamworkAPI = a.newTeamworkAPI(config)
 	}
 
 	return loginResponse, nil
@@ -240,6 +462,52 @@ func (a *App) DownloadTimeReport(startDate, endDate string) (string, error) {
 	return filePath, nil
 }
 
+func (a *App) GetMonthlyTimesheet(year, month int) (*api.MonthlyTimesheet, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada. Configure sua conta antes de exportar relatórios")
+	}
+
+	return a.teamworkAPI.BuildMonthlyTimesheet(year, month)
+}
+
+func (a *App) GenerateLocalTimesheetPDF(year, month int) (string, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return "", fmt.Errorf("API não configurada. Configure sua conta antes de exportar relatórios")
+	}
+
+	filePath, err := a.teamworkAPI.GenerateLocalTimesheetPDF(year, month)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar timesheet: %v", err)
+	}
+
+	return filePath, nil
+}
+
+func (a *App) GetComplianceReport(year, month int, options api.ComplianceOptions) (*api.ComplianceReport, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+
+	return a.teamworkAPI.GetComplianceReport(year, month, options)
+}
+
+func (a *App) GetExportColumns() []api.ExportColumn {
+	return api.GetExportColumns()
+}
+
+func (a *App) ExportTimeEntries(startDate, endDate string, options api.ExportOptions) (string, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return "", fmt.Errorf("API não configurada. Configure sua conta antes de exportar relatórios")
+	}
+
+	filePath, err := a.teamworkAPI.ExportTimeEntries(startDate, endDate, options)
+	if err != nil {
+		return "", fmt.Errorf("erro ao exportar lançamentos: %v", err)
+	}
+
+	return filePath, nil
+}
+
 func (a *App) OpenDirectoryPath(filePath string) error {
 	dirPath := filepath.Dir(filePath)
 
@@ -288,6 +556,27 @@ func (a *App) GetRecentActivities() ([]map[string]interface{}, error) {
 	return a.teamworkAPI.GetRecentActivities()
 }
 
+func (a *App) GetTaskBudget(taskID int) (*api.TaskBudget, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+	return a.teamworkAPI.GetTaskBudget(taskID)
+}
+
+func (a *App) GetTasklistBudget(tasklistID int) (*api.TasklistBudget, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+	return a.teamworkAPI.GetTasklistBudget(tasklistID)
+}
+
+func (a *App) GetActivityFeed(days, page, pageSize int) (*api.ActivityFeed, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+	return a.teamworkAPI.GetActivityFeed(days, page, pageSize)
+}
+
 func (a *App) GetTasksWithUpcomingDeadlines() ([]map[string]interface{}, error) {
 	if !a.teamworkAPI.IsConfigured() {
 		return nil, fmt.Errorf("API não configurada")
@@ -295,6 +584,13 @@ func (a *App) GetTasksWithUpcomingDeadlines() ([]map[string]interface{}, error)
 	return a.teamworkAPI.GetTasksWithUpcomingDeadlines()
 }
 
+func (a *App) GetDeadlineOverview(horizonDays int, includeOverdue bool) (*api.DeadlineOverview, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+	return a.teamworkAPI.GetDeadlineOverview(horizonDays, includeOverdue)
+}
+
 func (a *App) GetTimeTotalsForPeriod(startDate, endDate string) (*api.TimeTotal, error) {
 	if !a.teamworkAPI.IsConfigured() {
 		return nil, fmt.Errorf("API não configurada")
@@ -329,7 +625,23 @@ func (a *App) CreateDistributionPlanFromLoggedTime(month, year int, tasks []api.
 		return nil, fmt.Errorf("API não configurada")
 	}
 
-	return a.teamworkAPI.CreateDistributionPlanFromLoggedTime(month, year, tasks)
+	plan, err := a.teamworkAPI.CreateDistributionPlanFromLoggedTime(month, year, tasks)
+	if err != nil {
+		return nil, err
+	}
+	return a.teamworkAPI.AnnotatePlanBudgets(plan), nil
+}
+
+func (a *App) CreateGapFillingPlan(month, year int, tasks []api.Task) ([]api.WorkDay, error) {
+	if !a.teamworkAPI.IsConfigured() {
+		return nil, fmt.Errorf("API não configurada")
+	}
+
+	plan, err := a.teamworkAPI.CreateGapFillingPlan(month, year, tasks)
+	if err != nil {
+		return nil, err
+	}
+	return a.teamworkAPI.AnnotatePlanBudgets(plan), nil
 }
 
 func (a *App) GetEntriesFromLoggedTime(month, year int) ([]map[string]interface{}, error) {
@@ -356,6 +668,80 @@ func (a *App) GetHolidaysForMonth(year, month int) ([]api.Holiday, error) {
 	return a.teamworkAPI.GetHolidaysForMonth(year, month)
 }
 
+func (a *App) RefreshHolidays(year int) (map[string]api.Holiday, error) {
+	return a.teamworkAPI.RefreshHolidays(year)
+}
+
+func (a *App) ImportHolidays(content, fileName string) (*api.HolidayImportResult, error) {
+	return a.teamworkAPI.ImportHolidays(content, fileName)
+}
+
+func (a *App) GetHolidayRegions() []api.HolidayRegion {
+	return api.GetHolidayRegions()
+}
+
+func (a *App) GetHolidayCalendarSettings() api.HolidayCalendarSettings {
+	return a.configManager.GetHolidayCalendarSettings()
+}
+
+func (a *App) SaveHolidayCalendarSettings(settings api.HolidayCalendarSettings) error {
+	settings.State = strings.ToUpper(strings.TrimSpace(settings.State))
+	if err := api.ValidateHolidayCalendarSettings(settings); err != nil {
+		return err
+	}
+
+	if err := a.configManager.SetHolidayCalendarSettings(settings); err != nil {
+		return err
+	}
+
+	a.teamworkAPI.SetHolidayCalendar(settings)
+	return nil
+}
+
+func (a *App) GetHolidayCalendar(year int) (map[string]api.Holiday, error) {
+	return a.teamworkAPI.GetHolidayCalendar(year)
+}
+
+func (a *App) GetAbsences() []api.Absence {
+	return a.configManager.GetAbsences()
+}
+
+func (a *App) SaveAbsence(absence api.Absence) (api.Absence, error) {
+	absence.Type = strings.ToLower(strings.TrimSpace(absence.Type))
+	if absence.EndDate == "" {
+		absence.EndDate = absence.StartDate
+	}
+
+	if err := api.ValidateAbsence(absence); err != nil {
+		return absence, err
+	}
+
+	saved, err := a.configManager.SaveAbsence(absence)
+	if err != nil {
+		return saved, err
+	}
+
+	a.teamworkAPI.SetAbsences(a.configManager.GetAbsences())
+	return saved, nil
+}
+
+func (a *App) DeleteAbsence(id string) error {
+	if err := a.configManager.DeleteAbsence(id); err != nil {
+		return err
+	}
+
+	a.teamworkAPI.SetAbsences(a.configManager.GetAbsences())
+	return nil
+}
+
+func (a *App) GetExpectedMinutesForDay(date string) (*api.DayCapacity, error) {
+	return a.teamworkAPI.GetExpectedMinutesForDay(date)
+}
+
+func (a *App) GetExpectedMinutesForPeriod(startDate, endDate string) ([]api.DayCapacity, error) {
+	return a.teamworkAPI.GetExpectedMinutesForPeriod(startDate, endDate)
+}
+
 func (a *App) GetAllNonWorkingDays(year, month int) ([]map[string]interface{}, error) {
 	if !a.teamworkAPI.IsConfigured() {
 		return nil, fmt.Errorf("API não configurada")
@@ -453,17 +839,30 @@ func (a *App) ApplyTemplate(templateName string) error {
 	}
 
 	for _, task := range template.Tasks {
+		if err := api.ValidateTask(task); err != nil {
+			return err
+		}
+	}
+
+	for _, task := range template.Tasks {
+		entries := make([]api.TimeEntry, len(task.Entries))
+		for i, entry := range task.Entries {
+			entry.TagIDs = api.MergeTagIDs(entry.TagIDs, template.TagIDs)
+			entries[i] = entry
+		}
+		task.Entries = entries
+
 		err := a.configManager.AddSavedTask(task)
 		if err != nil {
-			return fmt.Errorf("erro ao aplicar tarefa do template: %v", err)
+			return a.syncSavedTasks(fmt.Errorf("erro ao aplicar tarefa do template: %v", err))
 		}
 	}
 
-	return nil
+	return a.syncSavedTasks(nil)
 }
 
 func (a *App) ClearSavedTasks() error {
-	return a.configManager.SetSavedTasks([]api.Task{})
+	return a.syncSavedTasks(a.configManager.SetSavedTasks([]api.Task{}))
 }
 
 func (a *App) GetTimeEntriesWithDetails(startDate, endDate string) ([]api.TimeEntryReport, error) {
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 )
 
 type Manager struct {
@@ -19,9 +20,15 @@ type Manager struct {
 }
 
 type AppConfig struct {
-	TeamworkConfig api.Config  `json:"teamworkConfig"`
-	SavedTasks     []api.Task  `json:"savedTasks"`
-	AppSettings    AppSettings `json:"appSettings"`
+	TeamworkConfig api.Config                  `json:"teamworkConfig"`
+	SavedTasks     []api.Task                  `json:"savedTasks"`
+	AppSettings    AppSettings                 `json:"appSettings"`
+	GitImport      api.GitImportSettings       `json:"gitImport"`
+	MappingRules   []api.MappingRule           `json:"mappingRules"`
+	LockedPeriods  []api.LockedPeriod          `json:"lockedPeriods"`
+	Holidays       api.HolidayCalendarSettings `json:"holidays"`
+	Absences       []api.Absence               `json:"absences"`
+	TaskUsage      map[int]api.TaskUsage       `json:"taskUsage"`
 }
 
 type AppSettings struct {
@@ -145,6 +152,241 @@ func (m *Manager) SetAppSettings(settings AppSettings) error {
 	return m.Save()
 }
 
+func (m *Manager) GetGitImportSettings() api.GitImportSettings {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.appConfig.GitImport
+}
+
+func (m *Manager) SetGitImportSettings(settings api.GitImportSettings) error {
+	m.mutex.Lock()
+	m.appConfig.GitImport = settings
+	m.mutex.Unlock()
+	return m.Save()
+}
+
+func (m *Manager) GetMappingRules() []api.MappingRule {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]api.MappingRule{}, m.appConfig.MappingRules...)
+}
+
+func (m *Manager) SetMappingRules(rules []api.MappingRule) error {
+	m.mutex.Lock()
+	m.appConfig.MappingRules = rules
+	m.mutex.Unlock()
+	return m.Save()
+}
+
+func (m *Manager) SaveMappingRule(rule api.MappingRule) (api.MappingRule, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if rule.ID == "" {
+		rule.ID = fmt.Sprintf("rule-%d", time.Now().UnixNano())
+	}
+
+	for i, r := range m.appConfig.MappingRules {
+		if r.ID == rule.ID {
+			m.appConfig.MappingRules[i] = rule
+			return rule, m.Save()
+		}
+	}
+
+	m.appConfig.MappingRules = append(m.appConfig.MappingRules, rule)
+	return rule, m.Save()
+}
+
+func (m *Manager) DeleteMappingRule(id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i, rule := range m.appConfig.MappingRules {
+		if rule.ID == id {
+			m.appConfig.MappingRules = append(m.appConfig.MappingRules[:i], m.appConfig.MappingRules[i+1:]...)
+			return m.Save()
+		}
+	}
+
+	return fmt.Errorf("regra não encontrada: %s", id)
+}
+
+func (m *Manager) ReorderMappingRules(ids []string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if len(ids) != len(m.appConfig.MappingRules) {
+		return fmt.Errorf("a nova ordem deve conter todas as %d regras", len(m.appConfig.MappingRules))
+	}
+
+	byID := make(map[string]api.MappingRule, len(m.appConfig.MappingRules))
+	for _, rule := range m.appConfig.MappingRules {
+		byID[rule.ID] = rule
+	}
+
+	reordered := make([]api.MappingRule, 0, len(ids))
+	for _, id := range ids {
+		rule, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("regra não encontrada: %s", id)
+		}
+		delete(byID, id)
+		reordered = append(reordered, rule)
+	}
+
+	m.appConfig.MappingRules = reordered
+	return m.Save()
+}
+
+func (m *Manager) GetHolidayCalendarSettings() api.HolidayCalendarSettings {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.appConfig.Holidays
+}
+
+func (m *Manager) SetHolidayCalendarSettings(settings api.HolidayCalendarSettings) error {
+	m.mutex.Lock()
+	m.appConfig.Holidays = settings
+	m.mutex.Unlock()
+	return m.Save()
+}
+
+func (m *Manager) GetAbsences() []api.Absence {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]api.Absence{}, m.appConfig.Absences...)
+}
+
+func (m *Manager) SaveAbsence(absence api.Absence) (api.Absence, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if absence.ID == "" {
+		absence.ID = fmt.Sprintf("absence-%d", time.Now().UnixNano())
+	}
+
+	for i, a := range m.appConfig.Absences {
+		if a.ID == absence.ID {
+			m.appConfig.Absences[i] = absence
+			return absence, m.Save()
+		}
+	}
+
+	m.appConfig.Absences = append(m.appConfig.Absences, absence)
+	return absence, m.Save()
+}
+
+func (m *Manager) DeleteAbsence(id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i, absence := range m.appConfig.Absences {
+		if absence.ID == id {
+			m.appConfig.Absences = append(m.appConfig.Absences[:i], m.appConfig.Absences[i+1:]...)
+			return m.Save()
+		}
+	}
+
+	return fmt.Errorf("ausência não encontrada: %s", id)
+}
+
+func (m *Manager) GetTaskUsage() map[int]api.TaskUsage {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	usage := make(map[int]api.TaskUsage, len(m.appConfig.TaskUsage))
+	for taskID, entry := range m.appConfig.TaskUsage {
+		usage[taskID] = entry
+	}
+	return usage
+}
+
+func (m *Manager) RecordTaskUsage(taskIDs []int) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.appConfig.TaskUsage == nil {
+		m.appConfig.TaskUsage = make(map[int]api.TaskUsage)
+	}
+
+	agora := time.Now().Format(time.RFC3339)
+	for _, taskID := range taskIDs {
+		entry := m.appConfig.TaskUsage[taskID]
+		entry.LastUsed = agora
+		entry.Count++
+		m.appConfig.TaskUsage[taskID] = entry
+	}
+	return m.Save()
+}
+
+func (m *Manager) GetLockedPeriods() []api.LockedPeriod {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]api.LockedPeriod{}, m.appConfig.LockedPeriods...)
+}
+
+func (m *Manager) LockPeriod(startDate, endDate, reason, by string) (api.LockedPeriod, error) {
+	period := api.LockedPeriod{
+		ID:        fmt.Sprintf("lock-%d", time.Now().UnixNano()),
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    reason,
+		Locked:    true,
+		History: []api.PeriodLockEvent{{
+			Action: "lock",
+			By:     by,
+			At:     time.Now().Format(time.RFC3339),
+			Reason: reason,
+		}},
+	}
+
+	if err := api.ValidateLockedPeriod(period); err != nil {
+		return period, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.appConfig.LockedPeriods = append(m.appConfig.LockedPeriods, period)
+	return period, m.Save()
+}
+
+func (m *Manager) SetPeriodLocked(id string, locked bool, reason, by string) (api.LockedPeriod, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for i, period := range m.appConfig.LockedPeriods {
+		if period.ID != id {
+			continue
+		}
+
+		if period.Locked == locked {
+			return period, nil
+		}
+
+		action := "unlock"
+		if locked {
+			action = "lock"
+		}
+
+		period.Locked = locked
+		period.History = append(append([]api.PeriodLockEvent{}, period.History...), api.PeriodLockEvent{
+			Action: action,
+			By:     by,
+			At:     time.Now().Format(time.RFC3339),
+			Reason: reason,
+		})
+		m.appConfig.LockedPeriods[i] = period
+		return period, m.Save()
+	}
+
+	return api.LockedPeriod{}, fmt.Errorf("período bloqueado não encontrado: %s", id)
+}
+
 func (m *Manager) GetTemplates() map[string]api.Template {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()